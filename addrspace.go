@@ -0,0 +1,151 @@
+package randomizer
+
+import (
+	"net/netip"
+	"slices"
+)
+
+// addrInterval is an inclusive range of addresses in integer form.
+type addrInterval struct {
+	lo, hi uint128
+}
+
+func (iv addrInterval) size() uint128 {
+	return iv.hi.sub(iv.lo).add64(1)
+}
+
+// prefixWidth returns the address width in bits of p's family.
+func prefixWidth(p netip.Prefix) int {
+	if p.Addr().Is4() {
+		return 32
+	}
+	return 128
+}
+
+func prefixInterval(p netip.Prefix) addrInterval {
+	p = p.Masked()
+	lo := u128FromAddr(p.Addr())
+	return addrInterval{lo, lo.or(lowMask128(prefixWidth(p) - p.Bits()))}
+}
+
+// mergeIntervals sorts ivs and coalesces overlapping or adjacent intervals.
+func mergeIntervals(ivs []addrInterval) []addrInterval {
+	slices.SortFunc(ivs, func(a, b addrInterval) int { return a.lo.cmp(b.lo) })
+	out := ivs[:0]
+	for _, iv := range ivs {
+		if n := len(out); n > 0 {
+			last := &out[n-1]
+			if last.hi.cmp(iv.lo) >= 0 || last.hi.add64(1) == iv.lo {
+				if iv.hi.cmp(last.hi) > 0 {
+					last.hi = iv.hi
+				}
+				continue
+			}
+		}
+		out = append(out, iv)
+	}
+	return out
+}
+
+// subtractIntervals returns the parts of include not covered by exclude.
+// Both inputs must be sorted and merged.
+func subtractIntervals(include, exclude []addrInterval) []addrInterval {
+	var out []addrInterval
+	for _, iv := range include {
+		covered := false
+		for _, ex := range exclude {
+			if ex.hi.cmp(iv.lo) < 0 {
+				continue
+			}
+			if ex.lo.cmp(iv.hi) > 0 {
+				break
+			}
+			if ex.lo.cmp(iv.lo) > 0 {
+				out = append(out, addrInterval{iv.lo, ex.lo.sub64(1)})
+			}
+			if ex.hi.cmp(iv.hi) >= 0 {
+				covered = true
+				break
+			}
+			iv.lo = ex.hi.add64(1)
+		}
+		if !covered {
+			out = append(out, iv)
+		}
+	}
+	return out
+}
+
+// addrSpace is a set of addresses of one family, stored as sorted disjoint
+// intervals, from which addresses can be drawn uniformly.
+type addrSpace struct {
+	is4   bool
+	ivs   []addrInterval
+	cum   []uint128 // cumulative interval sizes
+	total uint128   // number of addresses; zero if empty
+}
+
+// newAddrSpace builds the set of addresses covered by include but not by
+// exclude. Prefixes whose family differs from the first included prefix are
+// ignored. The resulting set must not span an entire 2^128 address space.
+func newAddrSpace(include, exclude []netip.Prefix) addrSpace {
+	var s addrSpace
+	if len(include) == 0 {
+		return s
+	}
+	s.is4 = include[0].Addr().Is4()
+	collect := func(ps []netip.Prefix) []addrInterval {
+		ivs := make([]addrInterval, 0, len(ps))
+		for _, p := range ps {
+			if p.IsValid() && p.Addr().Is4() == s.is4 {
+				ivs = append(ivs, prefixInterval(p))
+			}
+		}
+		return mergeIntervals(ivs)
+	}
+	s.ivs = subtractIntervals(collect(include), collect(exclude))
+	s.cum = make([]uint128, len(s.ivs))
+	for i, iv := range s.ivs {
+		s.total = s.total.add(iv.size())
+		s.cum[i] = s.total
+	}
+	return s
+}
+
+func (s *addrSpace) empty() bool {
+	return len(s.ivs) == 0
+}
+
+// contains reports whether a belongs to the set.
+func (s *addrSpace) contains(a netip.Addr) bool {
+	if a.Is4() != s.is4 {
+		return false
+	}
+	v := u128FromAddr(a)
+	_, found := slices.BinarySearchFunc(s.ivs, v, func(iv addrInterval, v uint128) int {
+		if iv.hi.cmp(v) < 0 {
+			return -1
+		}
+		if iv.lo.cmp(v) > 0 {
+			return 1
+		}
+		return 0
+	})
+	return found
+}
+
+// pick returns an address drawn uniformly from a non-empty set.
+func (s *addrSpace) pick(rng *wordRNG) netip.Addr {
+	v := uniformUint128n(s.total, rng)
+	i, _ := slices.BinarySearchFunc(s.cum, v, func(c, v uint128) int {
+		if c.cmp(v) <= 0 {
+			return -1
+		}
+		return 1
+	})
+	start := uint128{}
+	if i > 0 {
+		start = s.cum[i-1]
+	}
+	return s.ivs[i].lo.add(v.sub(start)).addr(s.is4)
+}
@@ -0,0 +1,157 @@
+// Package backoff computes retry delays with capped exponential growth and
+// the jitter strategies described in the AWS Architecture Blog post
+// "Exponential Backoff And Jitter". Delays are drawn from a randomizer.Rand,
+// so a schedule is reproducible under a fixed seed.
+//
+// ref: https://aws.amazon.com/blogs/architecture/exponential-backoff-and-jitter/
+package backoff
+
+import (
+	"math"
+	"time"
+
+	"github.com/colduction/randomizer"
+)
+
+// Defaults used when Config leaves a field zero.
+const (
+	DefaultBase       = 100 * time.Millisecond
+	DefaultCap        = 30 * time.Second
+	DefaultMultiplier = 2
+)
+
+// Jitter selects how a delay is randomized.
+type Jitter int
+
+const (
+	// NoJitter uses the capped exponential delay min(Cap, Base*Multiplier^n).
+	NoJitter Jitter = iota
+	// FullJitter draws uniformly from [0, d) where d is the capped
+	// exponential delay.
+	FullJitter
+	// EqualJitter keeps half of the capped exponential delay and draws the
+	// other half uniformly: d/2 + [0, d/2).
+	EqualJitter
+	// DecorrelatedJitter draws from [Base, 3*previous) capped at Cap, so each
+	// delay depends on the previous one rather than on the attempt number.
+	// Multiplier is not used.
+	DecorrelatedJitter
+)
+
+// Config describes a backoff schedule.
+type Config struct {
+	// Base is the first delay before jitter; zero means DefaultBase.
+	Base time.Duration
+	// Cap bounds every delay; zero means DefaultCap.
+	Cap time.Duration
+	// Multiplier is the growth factor per attempt; values below 1 mean
+	// DefaultMultiplier.
+	Multiplier float64
+	// Jitter selects the randomization strategy.
+	Jitter Jitter
+	// MaxElapsed stops the schedule once the time since the first delay was
+	// requested plus the next delay would exceed it; zero means no limit.
+	MaxElapsed time.Duration
+	// MaxAttempts stops the schedule after that many delays; zero means no
+	// limit.
+	MaxAttempts int
+	// Rand drives the jitter; nil means a Rand with a random seed. A Rand is
+	// not safe for concurrent use, so it must not be shared between Backoffs
+	// used from different goroutines.
+	Rand *randomizer.Rand
+}
+
+// Backoff produces the delays of one retry loop. It is not safe for
+// concurrent use.
+type Backoff struct {
+	cfg     Config
+	attempt int
+	prev    time.Duration
+	start   time.Time
+	now     func() time.Time
+}
+
+// New returns a Backoff following cfg; a nil cfg uses the defaults with
+// FullJitter.
+func New(cfg *Config) *Backoff {
+	c := Config{Jitter: FullJitter}
+	if cfg != nil {
+		c = *cfg
+	}
+	if c.Base <= 0 {
+		c.Base = DefaultBase
+	}
+	if c.Cap <= 0 {
+		c.Cap = DefaultCap
+	}
+	c.Base = min(c.Base, c.Cap)
+	if c.Multiplier < 1 {
+		c.Multiplier = DefaultMultiplier
+	}
+	if c.Rand == nil {
+		c.Rand = randomizer.NewRand(randomizer.Uint[uint64]())
+	}
+	return &Backoff{cfg: c, prev: c.Base, now: time.Now}
+}
+
+// Next returns the delay before the next attempt, or false once MaxAttempts
+// or MaxElapsed is reached.
+func (b *Backoff) Next() (time.Duration, bool) {
+	if b.cfg.MaxAttempts > 0 && b.attempt >= b.cfg.MaxAttempts {
+		return 0, false
+	}
+	now := b.now()
+	if b.start.IsZero() {
+		b.start = now
+	}
+	d := b.delay()
+	if b.cfg.MaxElapsed > 0 && now.Sub(b.start)+d > b.cfg.MaxElapsed {
+		return 0, false
+	}
+	b.attempt++
+	return d, true
+}
+
+// Attempt returns the number of delays returned since creation or the last
+// Reset.
+func (b *Backoff) Attempt() int {
+	return b.attempt
+}
+
+// Reset restarts the schedule from the first delay, keeping the Rand.
+func (b *Backoff) Reset() {
+	b.attempt, b.prev, b.start = 0, b.cfg.Base, time.Time{}
+}
+
+// delay computes the next delay for the configured strategy.
+func (b *Backoff) delay() time.Duration {
+	c := &b.cfg
+	if c.Jitter == DecorrelatedJitter {
+		hi := c.Cap
+		if b.prev < c.Cap/3 {
+			hi = 3 * b.prev
+		}
+		b.prev = min(c.Cap, between(c.Base, hi, c.Rand))
+		return b.prev
+	}
+
+	d := c.Cap
+	if exp := float64(c.Base) * math.Pow(c.Multiplier, float64(b.attempt)); exp < float64(c.Cap) {
+		d = time.Duration(exp)
+	}
+	switch c.Jitter {
+	case FullJitter:
+		return between(0, d, c.Rand)
+	case EqualJitter:
+		return d/2 + between(0, d-d/2, c.Rand)
+	}
+	return d
+}
+
+// between returns a uniform duration in [lo, hi), or lo if hi <= lo.
+func between(lo, hi time.Duration, r *randomizer.Rand) time.Duration {
+	if hi <= lo {
+		return lo
+	}
+	return lo + time.Duration(r.Uint64n(uint64(hi-lo)))
+}
@@ -0,0 +1,183 @@
+package backoff_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/colduction/randomizer"
+	"github.com/colduction/randomizer/backoff"
+)
+
+func delays(b *backoff.Backoff, n int) []time.Duration {
+	var out []time.Duration
+	for range n {
+		d, ok := b.Next()
+		if !ok {
+			break
+		}
+		out = append(out, d)
+	}
+	return out
+}
+
+func TestCappedExponential(t *testing.T) {
+	b := backoff.New(&backoff.Config{Base: 100 * time.Millisecond, Cap: time.Second, Jitter: backoff.NoJitter})
+	want := []time.Duration{100, 200, 400, 800, 1000, 1000}
+	got := delays(b, len(want))
+	for i := range want {
+		if got[i] != want[i]*time.Millisecond {
+			t.Fatalf("delays = %v, want %v ms", got, want)
+		}
+	}
+	if b.Attempt() != len(want) {
+		t.Fatalf("Attempt() = %d, want %d", b.Attempt(), len(want))
+	}
+	b.Reset()
+	if d, _ := b.Next(); d != 100*time.Millisecond || b.Attempt() != 1 {
+		t.Fatalf("after Reset: delay %v, attempt %d", d, b.Attempt())
+	}
+}
+
+func TestJitterBounds(t *testing.T) {
+	const base, limit = 10 * time.Millisecond, 500 * time.Millisecond
+	for _, jitter := range []backoff.Jitter{backoff.FullJitter, backoff.EqualJitter, backoff.DecorrelatedJitter} {
+		b := backoff.New(&backoff.Config{Base: base, Cap: limit, Jitter: jitter, Rand: randomizer.NewRand(7)})
+		prev := base
+		for n := range 200 {
+			d, ok := b.Next()
+			if !ok {
+				t.Fatalf("jitter %d: schedule ended at attempt %d", jitter, n)
+			}
+			exp := min(limit, base<<min(n, 20))
+			var lo, hi time.Duration
+			switch jitter {
+			case backoff.FullJitter:
+				lo, hi = 0, exp
+			case backoff.EqualJitter:
+				lo, hi = exp/2, exp
+			case backoff.DecorrelatedJitter:
+				lo, hi = base, min(limit, 3*prev)
+			}
+			if d < lo || d > hi || (d == hi && jitter != backoff.DecorrelatedJitter) {
+				t.Fatalf("jitter %d attempt %d: delay %v outside [%v, %v)", jitter, n, d, lo, hi)
+			}
+			prev = d
+		}
+	}
+}
+
+func TestReproducible(t *testing.T) {
+	cfg := func() *backoff.Config {
+		return &backoff.Config{Jitter: backoff.DecorrelatedJitter, Rand: randomizer.NewRand(42)}
+	}
+	a, b := delays(backoff.New(cfg()), 20), delays(backoff.New(cfg()), 20)
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatalf("schedules with equal seeds differ at %d: %v vs %v", i, a[i], b[i])
+		}
+	}
+}
+
+func TestLimits(t *testing.T) {
+	b := backoff.New(&backoff.Config{MaxAttempts: 3})
+	if got := delays(b, 10); len(got) != 3 {
+		t.Fatalf("MaxAttempts 3 gave %d delays", len(got))
+	}
+	b = backoff.New(&backoff.Config{Base: 10 * time.Millisecond, Jitter: backoff.NoJitter, MaxElapsed: 35 * time.Millisecond})
+	if got := delays(b, 10); len(got) != 2 {
+		t.Fatalf("MaxElapsed gave delays %v, want 10ms and 20ms", got)
+	}
+}
+
+func TestRetry(t *testing.T) {
+	cfg := &backoff.Config{Base: time.Millisecond, Cap: 2 * time.Millisecond}
+	errFlaky := errors.New("flaky")
+
+	calls := 0
+	err := backoff.Retry(context.Background(), cfg, func(context.Context) error {
+		if calls++; calls < 4 {
+			return errFlaky
+		}
+		return nil
+	})
+	if err != nil || calls != 4 {
+		t.Fatalf("Retry = %v after %d calls, want success after 4", err, calls)
+	}
+
+	calls = 0
+	err = backoff.Retry(context.Background(), cfg, func(context.Context) error {
+		calls++
+		return backoff.Permanent(errFlaky)
+	})
+	if err != errFlaky || calls != 1 {
+		t.Fatalf("permanent: Retry = %v after %d calls", err, calls)
+	}
+
+	calls = 0
+	limited := *cfg
+	limited.MaxAttempts = 2
+	err = backoff.Retry(context.Background(), &limited, func(context.Context) error {
+		calls++
+		return errFlaky
+	})
+	if err != errFlaky || calls != 3 {
+		t.Fatalf("exhausted: Retry = %v after %d calls, want 3", err, calls)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	slow := &backoff.Config{Base: time.Hour, Cap: time.Hour, Jitter: backoff.NoJitter}
+	err = backoff.Retry(ctx, slow, func(context.Context) error {
+		cancel()
+		return errFlaky
+	})
+	if !errors.Is(err, context.Canceled) || !errors.Is(err, errFlaky) {
+		t.Fatalf("canceled: Retry = %v, want both context.Canceled and the last error", err)
+	}
+}
+
+func TestTicker(t *testing.T) {
+	tk := backoff.NewTicker(5*time.Millisecond, 0.5, randomizer.NewRand(1))
+	deadline := time.After(5 * time.Second)
+	prev := time.Now()
+	for range 5 {
+		select {
+		case now := <-tk.C:
+			if gap := now.Sub(prev); gap < 2*time.Millisecond {
+				t.Fatalf("tick after %v, want at least half the period", gap)
+			}
+			prev = now
+		case <-deadline:
+			t.Fatal("ticker did not tick")
+		}
+	}
+
+	tk.Reset(time.Hour)
+	select {
+	case <-tk.C:
+	default:
+	}
+	select {
+	case <-tk.C:
+		t.Fatal("tick after Reset to an hour")
+	case <-time.After(30 * time.Millisecond):
+	}
+
+	tk.Reset(time.Millisecond)
+	select {
+	case <-tk.C:
+	case <-time.After(5 * time.Second):
+		t.Fatal("no tick after Reset")
+	}
+	tk.Stop()
+	select {
+	case <-tk.C:
+	default:
+	}
+	select {
+	case <-tk.C:
+		t.Fatal("tick after Stop")
+	case <-time.After(30 * time.Millisecond):
+	}
+}
@@ -0,0 +1,64 @@
+package backoff
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// PermanentError wraps an error that Retry must not retry.
+type PermanentError struct {
+	Err error
+}
+
+func (e *PermanentError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *PermanentError) Unwrap() error {
+	return e.Err
+}
+
+// Permanent wraps err so that Retry returns it without further attempts. It
+// returns nil if err is nil.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &PermanentError{Err: err}
+}
+
+// Retry calls fn until it succeeds, returns a permanent error, the schedule
+// of cfg is exhausted or ctx is done, waiting for the backoff delay between
+// calls. It returns nil on success, the unwrapped error of a permanent
+// failure, the last error once the schedule is exhausted, and ctx's error
+// joined with the last error if ctx is done first.
+func Retry(ctx context.Context, cfg *Config, fn func(context.Context) error) error {
+	b := New(cfg)
+	var timer *time.Timer
+	for {
+		err := fn(ctx)
+		if err == nil {
+			return nil
+		}
+		if perm, ok := errors.AsType[*PermanentError](err); ok {
+			return perm.Err
+		}
+		d, ok := b.Next()
+		if !ok {
+			return err
+		}
+		if timer == nil {
+			timer = time.NewTimer(d)
+			defer timer.Stop()
+		} else {
+			timer.Reset(d)
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("backoff: %w after %d attempts: %w", context.Cause(ctx), b.Attempt(), err)
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package backoff
+
+import (
+	"sync"
+	"time"
+
+	"github.com/colduction/randomizer"
+)
+
+// Ticker delivers ticks like a time.Ticker, but each interval is drawn
+// uniformly from [period*(1-jitter), period*(1+jitter)), which keeps many
+// tickers started together from firing in lockstep.
+type Ticker struct {
+	// C delivers the ticks. Like time.Ticker, it holds at most one pending
+	// tick and drops ticks for slow receivers.
+	C <-chan time.Time
+
+	c       chan time.Time
+	mu      sync.Mutex
+	timer   *time.Timer
+	period  time.Duration
+	jitter  float64
+	r       *randomizer.Rand
+	gen     uint64 // incremented by Stop and Reset to drop in-flight ticks
+	stopped bool
+}
+
+// NewTicker returns a started Ticker with the given mean period and jitter
+// fraction, which is clamped to [0, 1]. A nil r means a Rand with a random
+// seed; the Ticker takes ownership of r. NewTicker panics if period is not
+// positive, as time.NewTicker does.
+func NewTicker(period time.Duration, jitter float64, r *randomizer.Rand) *Ticker {
+	if period <= 0 {
+		panic("backoff: non-positive interval for NewTicker")
+	}
+	if r == nil {
+		r = randomizer.NewRand(randomizer.Uint[uint64]())
+	}
+	c := make(chan time.Time, 1)
+	t := &Ticker{C: c, c: c, period: period, jitter: min(max(jitter, 0), 1), r: r}
+	t.mu.Lock()
+	t.schedule()
+	t.mu.Unlock()
+	return t
+}
+
+// Stop turns off the ticker. It does not close C.
+func (t *Ticker) Stop() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.stopped = true
+	t.gen++
+	t.timer.Stop()
+}
+
+// Reset stops the ticker and restarts it with a new mean period. It panics if
+// period is not positive.
+func (t *Ticker) Reset(period time.Duration) {
+	if period <= 0 {
+		panic("backoff: non-positive interval for Ticker.Reset")
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.period, t.stopped = period, false
+	t.gen++
+	t.timer.Stop()
+	t.schedule()
+}
+
+// schedule arms a timer for the next tick of the current generation; t.mu
+// must be held.
+func (t *Ticker) schedule() {
+	gen := t.gen
+	t.timer = time.AfterFunc(t.interval(), func() { t.tick(gen) })
+}
+
+func (t *Ticker) tick(gen uint64) {
+	now := time.Now()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.stopped || gen != t.gen {
+		return
+	}
+	select {
+	case t.c <- now:
+	default:
+	}
+	t.schedule()
+}
+
+// interval draws the next interval; t.mu must be held.
+func (t *Ticker) interval() time.Duration {
+	spread := time.Duration(float64(t.period) * t.jitter)
+	return max(between(t.period-spread, t.period+spread, t.r), 1)
+}
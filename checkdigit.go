@@ -0,0 +1,369 @@
+package randomizer
+
+import (
+	"slices"
+	"strings"
+)
+
+// ibanFormat describes a country's BBAN in SWIFT registry notation, where each
+// run is a length followed by n (digits), a (uppercase letters) or c (uppercase
+// letters and digits).
+type ibanFormat struct {
+	country string
+	bban    string
+}
+
+// ref: https://www.swift.com/standards/data-standards/iban-international-bank-account-number
+// Entries are sorted by country code.
+var ibanFormats = []ibanFormat{
+	{"AD", "4n4n12c"},
+	{"AE", "3n16n"},
+	{"AL", "8n16c"},
+	{"AT", "5n11n"},
+	{"AZ", "4a20c"},
+	{"BA", "3n3n8n2n"},
+	{"BE", "3n7n2n"},
+	{"BG", "4a4n2n8c"},
+	{"BH", "4a14c"},
+	{"BR", "8n5n10n1a1c"},
+	{"CH", "5n12c"},
+	{"CR", "4n14n"},
+	{"CY", "3n5n16c"},
+	{"CZ", "4n6n10n"},
+	{"DE", "8n10n"},
+	{"DK", "4n9n1n"},
+	{"DO", "4c20n"},
+	{"EE", "2n2n11n1n"},
+	{"ES", "4n4n1n1n10n"},
+	{"FI", "3n11n"},
+	{"FO", "4n9n1n"},
+	{"FR", "5n5n11c2n"},
+	{"GB", "4a6n8n"},
+	{"GE", "2a16n"},
+	{"GI", "4a15c"},
+	{"GL", "4n9n1n"},
+	{"GR", "3n4n16c"},
+	{"GT", "4c20c"},
+	{"HR", "7n10n"},
+	{"HU", "3n4n1n15n1n"},
+	{"IE", "4a6n8n"},
+	{"IL", "3n3n13n"},
+	{"IS", "4n2n6n10n"},
+	{"IT", "1a5n5n12c"},
+	{"JO", "4a4n18c"},
+	{"KW", "4a22c"},
+	{"KZ", "3n13c"},
+	{"LB", "4n20c"},
+	{"LI", "5n12c"},
+	{"LT", "5n11n"},
+	{"LU", "3n13c"},
+	{"LV", "4a13c"},
+	{"MC", "5n5n11c2n"},
+	{"MD", "2c18c"},
+	{"ME", "3n13n2n"},
+	{"MK", "3n10c2n"},
+	{"MR", "5n5n11n2n"},
+	{"MT", "4a5n18c"},
+	{"MU", "4a2n2n12n3n3a"},
+	{"NL", "4a10n"},
+	{"NO", "4n6n1n"},
+	{"PK", "4a16c"},
+	{"PL", "8n16n"},
+	{"PS", "4a21c"},
+	{"PT", "4n4n11n2n"},
+	{"QA", "4a21c"},
+	{"RO", "4a16c"},
+	{"RS", "3n13n2n"},
+	{"SA", "2n18c"},
+	{"SE", "3n16n1n"},
+	{"SI", "5n8n2n"},
+	{"SK", "4n6n10n"},
+	{"SM", "1a5n5n12c"},
+	{"TN", "2n3n13n2n"},
+	{"TR", "5n1n16c"},
+	{"UA", "6n19c"},
+	{"VG", "4a16n"},
+	{"XK", "4n10n2n"},
+}
+
+// vinTranslit maps VIN characters to their check digit values.
+// ref: https://en.wikipedia.org/wiki/Vehicle_identification_number#Check-digit_calculation
+var vinTranslit = [256]int8{
+	'0': 0, '1': 1, '2': 2, '3': 3, '4': 4, '5': 5, '6': 6, '7': 7, '8': 8, '9': 9,
+	'A': 1, 'B': 2, 'C': 3, 'D': 4, 'E': 5, 'F': 6, 'G': 7, 'H': 8,
+	'J': 1, 'K': 2, 'L': 3, 'M': 4, 'N': 5, 'P': 7, 'R': 9,
+	'S': 2, 'T': 3, 'U': 4, 'V': 5, 'W': 6, 'X': 7, 'Y': 8, 'Z': 9,
+}
+
+var vinWeights = [17]int{8, 7, 6, 5, 4, 3, 2, 10, 0, 9, 8, 7, 6, 5, 4, 3, 2}
+
+const (
+	// vindict excludes I, O and Q, which ISO 3779 forbids.
+	vindict string = "0123456789ABCDEFGHJKLMNPRSTUVWXYZ"
+	// vinYeardict excludes U, Z and 0, which are not used as model year codes.
+	vinYeardict string = "ABCDEFGHJKLMNPRSTVWXY123456789"
+)
+
+// imeiReportingBodies are common IMEI reporting body identifiers (first two TAC digits).
+var imeiReportingBodies = [...]string{"01", "35", "86", "99"}
+
+func lookupIBANFormat(country string) (ibanFormat, bool) {
+	i, ok := slices.BinarySearchFunc(ibanFormats, country, func(f ibanFormat, c string) int {
+		return strings.Compare(f.country, c)
+	})
+	if !ok {
+		return ibanFormat{}, false
+	}
+	return ibanFormats[i], true
+}
+
+// forEachIBANRun calls fn for every run of the BBAN format.
+func forEachIBANRun(bban string, fn func(n int, kind byte)) {
+	n := 0
+	for i := 0; i < len(bban); i++ {
+		c := bban[i]
+		if c >= '0' && c <= '9' {
+			n = n*10 + int(c-'0')
+			continue
+		}
+		fn(n, c)
+		n = 0
+	}
+}
+
+func ibanDict(kind byte) string {
+	switch kind {
+	case 'a':
+		return ualphadict
+	case 'c':
+		return ualnumdict
+	default:
+		return deci
+	}
+}
+
+// ibanMod97 returns the ISO 7064 mod 97-10 remainder of an IBAN whose check
+// digits are in s[2:4], rearranging and transliterating letters on the fly.
+func ibanMod97(s []byte) int {
+	rem := 0
+	for i := range s {
+		c := s[(i+4)%len(s)]
+		switch {
+		case c >= '0' && c <= '9':
+			rem = (rem*10 + int(c-'0')) % 97
+		case c >= 'A' && c <= 'Z':
+			rem = (rem*100 + int(c-'A') + 10) % 97
+		default:
+			return -1
+		}
+	}
+	return rem
+}
+
+// IBAN generates a syntactically valid IBAN in electronic format (no spaces)
+// for the ISO 3166-1 alpha-2 country code, with a BBAN matching the country's
+// registered structure and correct mod-97 check digits. National check digits
+// inside the BBAN are not computed. If country is empty, a registered country
+// is chosen at random; an unknown country yields an empty string.
+func (w word) IBAN(country string) string {
+	rng := w.r.source()
+	var f ibanFormat
+	if country == "" {
+		f = ibanFormats[uniformUint64n(uint64(len(ibanFormats)), rng)]
+	} else {
+		var ok bool
+		if f, ok = lookupIBANFormat(strings.ToUpper(country)); !ok {
+			return ""
+		}
+	}
+	out := make([]byte, 4, 4+34)
+	copy(out, f.country)
+	out[2], out[3] = '0', '0'
+	forEachIBANRun(f.bban, func(n int, kind byte) {
+		l := len(out)
+		out = out[:l+n]
+		fillAlphabet(out[l:], ibanDict(kind), rng)
+	})
+	check := 98 - ibanMod97(out)
+	out[2], out[3] = deci[check/10], deci[check%10]
+	return string(out)
+}
+
+// IBANValid reports whether s is an IBAN in electronic format for a registered
+// country, with a BBAN matching the country's structure and valid check digits.
+func (word) IBANValid(s string) bool {
+	if len(s) < 5 {
+		return false
+	}
+	f, ok := lookupIBANFormat(s[:2])
+	if !ok || !isDigits(s[2:4]) {
+		return false
+	}
+	pos := 4
+	valid := true
+	forEachIBANRun(f.bban, func(n int, kind byte) {
+		if !valid || pos+n > len(s) {
+			valid = false
+			return
+		}
+		dict := ibanDict(kind)
+		for i := pos; i < pos+n; i++ {
+			if strings.IndexByte(dict, s[i]) < 0 {
+				valid = false
+				return
+			}
+		}
+		pos += n
+	})
+	return valid && pos == len(s) && ibanMod97([]byte(s)) == 1
+}
+
+// gtinCheckDigit returns the GS1 mod-10 check digit for payload, weighting
+// digits 3 and 1 alternately from the right.
+func gtinCheckDigit(payload []byte) byte {
+	sum := 0
+	for i := range payload {
+		d := int(payload[len(payload)-1-i] - '0')
+		if i%2 == 0 {
+			d *= 3
+		}
+		sum += d
+	}
+	return deci[(10-sum%10)%10]
+}
+
+func gtinValid(s string, length int) bool {
+	if len(s) != length || !isDigits(s) {
+		return false
+	}
+	return gtinCheckDigit([]byte(s[:length-1])) == s[length-1]
+}
+
+// gtin generates a random GS1 number of the given length starting with prefix.
+func gtin(length int, prefix string, rng *wordRNG) string {
+	out := make([]byte, length)
+	n := copy(out, prefix)
+	fillDecimal(out[n:length-1], rng)
+	out[length-1] = gtinCheckDigit(out[:length-1])
+	return string(out)
+}
+
+func isbn10CheckDigit(payload []byte) byte {
+	sum := 0
+	for i, c := range payload {
+		sum += (10 - i) * int(c-'0')
+	}
+	check := (11 - sum%11) % 11
+	if check == 10 {
+		return 'X'
+	}
+	return deci[check]
+}
+
+// ISBN10 generates a random ISBN-10 without hyphens. The check character is
+// a digit or X.
+func (w word) ISBN10() string {
+	out := make([]byte, 10)
+	rng := w.r.source()
+	fillDecimal(out[:9], rng)
+	out[9] = isbn10CheckDigit(out[:9])
+	return string(out)
+}
+
+// ISBN10Valid reports whether s is an ISBN-10 without hyphens with a valid check character.
+func (word) ISBN10Valid(s string) bool {
+	if len(s) != 10 || !isDigits(s[:9]) {
+		return false
+	}
+	return isbn10CheckDigit([]byte(s[:9])) == s[9]
+}
+
+// ISBN13 generates a random ISBN-13 without hyphens using the 978 or 979
+// Bookland prefix.
+func (w word) ISBN13() string {
+	rng := w.r.source()
+	prefix := "978"
+	if rng.next64()&1 == 1 {
+		prefix = "979"
+	}
+	return gtin(13, prefix, rng)
+}
+
+// ISBN13Valid reports whether s is an ISBN-13 without hyphens with a Bookland
+// prefix and a valid check digit.
+func (word) ISBN13Valid(s string) bool {
+	return gtinValid(s, 13) && (s[:3] == "978" || s[:3] == "979")
+}
+
+// EAN13 generates a random EAN-13 barcode number with a valid check digit.
+func (w word) EAN13() string {
+	return gtin(13, "", w.r.source())
+}
+
+// EAN13Valid reports whether s is a 13-digit number with a valid EAN check digit.
+func (word) EAN13Valid(s string) bool {
+	return gtinValid(s, 13)
+}
+
+// UPCA generates a random 12-digit UPC-A barcode number with a valid check digit.
+func (w word) UPCA() string {
+	return gtin(12, "", w.r.source())
+}
+
+// UPCAValid reports whether s is a 12-digit number with a valid UPC-A check digit.
+func (word) UPCAValid(s string) bool {
+	return gtinValid(s, 12)
+}
+
+func vinCheckDigit(vin []byte) byte {
+	sum := 0
+	for i, c := range vin {
+		sum += int(vinTranslit[c]) * vinWeights[i]
+	}
+	check := sum % 11
+	if check == 10 {
+		return 'X'
+	}
+	return deci[check]
+}
+
+// VIN generates a random 17-character ISO 3779 vehicle identification number.
+// It avoids the letters I, O and Q, uses a valid model year code in position
+// 10 and sets the North American check digit in position 9.
+func (w word) VIN() string {
+	out := make([]byte, 17)
+	rng := w.r.source()
+	fillAlphabet(out, vindict, rng)
+	out[9] = vinYeardict[uniformUint64n(uint64(len(vinYeardict)), rng)]
+	out[8] = vinCheckDigit(out)
+	return string(out)
+}
+
+// VINValid reports whether s is a 17-character VIN using only permitted
+// characters and carrying a valid check digit in position 9.
+func (word) VINValid(s string) bool {
+	if len(s) != 17 {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if strings.IndexByte(vindict, s[i]) < 0 {
+			return false
+		}
+	}
+	return vinCheckDigit([]byte(s)) == s[8]
+}
+
+// IMEI generates a random 15-digit IMEI with a common reporting body
+// identifier and a valid Luhn check digit.
+func (w word) IMEI() string {
+	out := make([]byte, 15)
+	rng := w.r.source()
+	rb := imeiReportingBodies[uniformUint64n(uint64(len(imeiReportingBodies)), rng)]
+	luhnFill(out, rb, rng)
+	return string(out)
+}
+
+// IMEIValid reports whether s is a 15-digit IMEI with a valid Luhn check digit.
+func (word) IMEIValid(s string) bool {
+	return len(s) == 15 && Word.LuhnValid(s)
+}
@@ -0,0 +1,111 @@
+package randomizer_test
+
+import (
+	"testing"
+
+	"github.com/colduction/randomizer"
+)
+
+func TestWordIBANValidKnown(t *testing.T) {
+	valid := []string{
+		"GB82WEST12345698765432",
+		"DE89370400440532013000",
+		"FR1420041010050500013M02606",
+		"NL91ABNA0417164300",
+		"BE68539007547034",
+		"CH9300762011623852957",
+		"NO9386011117947",
+		"MT84MALT011000012345MTLCAST001S",
+		"BR1800360305000010009795493C1",
+		"MU17BOMM0101101030300200000MUR",
+	}
+	for _, s := range valid {
+		if !randomizer.Word.IBANValid(s) {
+			t.Fatalf("IBANValid(%q) = false, want true", s)
+		}
+	}
+	invalid := []string{
+		"GB83WEST12345698765432",
+		"GB82WEST1234569876543",
+		"GB82west12345698765432",
+		"ZZ82WEST12345698765432",
+		"",
+	}
+	for _, s := range invalid {
+		if randomizer.Word.IBANValid(s) {
+			t.Fatalf("IBANValid(%q) = true, want false", s)
+		}
+	}
+}
+
+func TestWordIBAN(t *testing.T) {
+	for _, country := range []string{"DE", "gb", "FR", "MU", ""} {
+		for range 200 {
+			s := randomizer.Word.IBAN(country)
+			if !randomizer.Word.IBANValid(s) {
+				t.Fatalf("IBAN(%q) generated invalid IBAN %q", country, s)
+			}
+		}
+	}
+	if got := randomizer.Word.IBAN("DE")[:2]; got != "DE" {
+		t.Fatalf("IBAN(\"DE\") country = %q", got)
+	}
+	if got := randomizer.Word.IBAN("ZZ"); got != "" {
+		t.Fatalf("IBAN(\"ZZ\") = %q, want empty string", got)
+	}
+}
+
+func TestWordProductCodes(t *testing.T) {
+	known := []struct {
+		valid func(string) bool
+		in    string
+		want  bool
+	}{
+		{randomizer.Word.ISBN10Valid, "0306406152", true},
+		{randomizer.Word.ISBN10Valid, "080442957X", true},
+		{randomizer.Word.ISBN10Valid, "0306406153", false},
+		{randomizer.Word.ISBN13Valid, "9780306406157", true},
+		{randomizer.Word.ISBN13Valid, "4006381333931", false},
+		{randomizer.Word.EAN13Valid, "4006381333931", true},
+		{randomizer.Word.EAN13Valid, "4006381333932", false},
+		{randomizer.Word.UPCAValid, "036000291452", true},
+		{randomizer.Word.UPCAValid, "036000291453", false},
+		{randomizer.Word.VINValid, "1M8GDM9AXKP042788", true},
+		{randomizer.Word.VINValid, "1M8GDM9A1KP042788", false},
+		{randomizer.Word.VINValid, "1M8GDM9AXKP04278O", false},
+		{randomizer.Word.IMEIValid, "490154203237518", true},
+		{randomizer.Word.IMEIValid, "490154203237519", false},
+	}
+	for _, tc := range known {
+		if got := tc.valid(tc.in); got != tc.want {
+			t.Fatalf("validator(%q) = %t, want %t", tc.in, got, tc.want)
+		}
+	}
+
+	gens := []struct {
+		name  string
+		gen   func() string
+		valid func(string) bool
+	}{
+		{"ISBN10", randomizer.Word.ISBN10, randomizer.Word.ISBN10Valid},
+		{"ISBN13", randomizer.Word.ISBN13, randomizer.Word.ISBN13Valid},
+		{"EAN13", randomizer.Word.EAN13, randomizer.Word.EAN13Valid},
+		{"UPCA", randomizer.Word.UPCA, randomizer.Word.UPCAValid},
+		{"VIN", randomizer.Word.VIN, randomizer.Word.VINValid},
+		{"IMEI", randomizer.Word.IMEI, randomizer.Word.IMEIValid},
+	}
+	for _, g := range gens {
+		for range 1000 {
+			if s := g.gen(); !g.valid(s) {
+				t.Fatalf("%s generated invalid value %q", g.name, s)
+			}
+		}
+	}
+}
+
+func BenchmarkWordIBAN(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		benchWordString = randomizer.Word.IBAN("DE")
+	}
+}
@@ -0,0 +1,302 @@
+package randomizer
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// DNS name limits.
+// ref: https://datatracker.ietf.org/doc/html/rfc1035#section-2.3.4
+const (
+	maxLabelLen  = 63
+	maxDomainLen = 253
+)
+
+// TLDSource selects where Network.Domain takes its top-level suffix from.
+type TLDSource uint8
+
+const (
+	// PublicSuffixTLD draws from an embedded snapshot of the public suffix list.
+	PublicSuffixTLD TLDSource = iota
+	// ReservedTLD draws from the TLDs reserved for testing and documentation
+	// by RFC 2606 and RFC 6761: test, example, invalid and localhost.
+	ReservedTLD
+)
+
+// publicSuffixes is a snapshot of common ICANN entries of the public suffix
+// list, including internationalized TLDs in their ASCII form.
+// ref: https://publicsuffix.org/list/public_suffix_list.dat
+var publicSuffixes = [...]string{
+	"com", "net", "org", "info", "biz", "io", "dev", "app", "xyz", "online",
+	"de", "fr", "nl", "eu", "ch", "se", "it", "es", "pl", "ru", "ca", "us",
+	"co.uk", "org.uk", "ac.uk", "com.au", "net.au", "co.jp", "ne.jp", "jp",
+	"com.br", "com.cn", "cn", "co.in", "in", "co.za", "com.mx", "co.nz",
+	"xn--p1ai", "xn--fiqs8s", "xn--wgbh1c",
+}
+
+var reservedTLDs = [...]string{"test", "example", "invalid", "localhost"}
+
+const (
+	ldhAlnum string = "abcdefghijklmnopqrstuvwxyz0123456789"
+	ldhInner string = "abcdefghijklmnopqrstuvwxyz0123456789-"
+)
+
+// idnScripts are lowercase letter ranges used for internationalized labels.
+// Each label draws from a single range to keep it a plausible IDNA name.
+var idnScripts = [...][2]rune{
+	{0x00E0, 0x00F6}, // Latin-1 lowercase letters à-ö
+	{0x03B1, 0x03C1}, // Greek lowercase letters α-ρ
+	{0x0430, 0x044F}, // Cyrillic lowercase letters а-я
+	{0x4E00, 0x9FA5}, // CJK unified ideographs
+}
+
+// DomainOptions configures Network.Hostname and Network.Domain.
+type DomainOptions struct {
+	// Labels is the number of labels before the TLD; zero means one.
+	Labels int
+	// MinLabelLen and MaxLabelLen bound the length of each label in bytes.
+	// Zero values default to 3 and 12; both are clamped to [1, 63].
+	MinLabelLen, MaxLabelLen int
+	// TLDSource selects the suffix source used when TLD is empty.
+	TLDSource TLDSource
+	// TLD fixes the suffix, which may contain dots (e.g. "co.uk").
+	TLD string
+	// IDN makes some labels internationalized, encoded with Punycode as
+	// "xn--" A-labels.
+	IDN bool
+}
+
+func (o *DomainOptions) labelBounds() (int, int) {
+	lo, hi := o.MinLabelLen, o.MaxLabelLen
+	if lo <= 0 {
+		lo = 3
+	}
+	if hi <= 0 {
+		hi = max(12, lo)
+	}
+	lo = min(lo, maxLabelLen)
+	hi = min(max(hi, lo), maxLabelLen)
+	return lo, hi
+}
+
+// tld returns the normalized TLD option, reporting false if it is set but
+// not a valid suffix.
+func (o *DomainOptions) tld() (string, bool) {
+	if o == nil || o.TLD == "" {
+		return "", true
+	}
+	tld := strings.TrimPrefix(strings.ToLower(o.TLD), ".")
+	return tld, validSuffix(tld)
+}
+
+// appendLDHLabel appends an RFC 1123 label of length n: letters, digits and
+// interior hyphens, never two hyphens in a row.
+func appendLDHLabel(out []byte, n int, rng *wordRNG) []byte {
+	for i := range n {
+		dict := ldhInner
+		if i == 0 || i == n-1 || out[len(out)-1] == '-' {
+			dict = ldhAlnum
+		}
+		out = append(out, dict[uniformUint64n(uint64(len(dict)), rng)])
+	}
+	return out
+}
+
+// appendIDNLabel appends the A-label of a random internationalized label
+// whose encoded form fits in n bytes. It reports false if none fits.
+func appendIDNLabel(out []byte, n int, rng *wordRNG) ([]byte, bool) {
+	const prefix = "xn--"
+	if n < len(prefix)+2 {
+		return out, false
+	}
+	script := idnScripts[uniformUint64n(uint64(len(idnScripts)), rng)]
+	runes := make([]rune, 1+uniformUint64n(uint64(n-len(prefix)), rng))
+	for i := range runes {
+		runes[i] = script[0] + rune(uniformUint64n(uint64(script[1]-script[0]+1), rng))
+	}
+	for ; len(runes) > 0; runes = runes[:len(runes)-1] {
+		if enc := punycodeEncode(runes); len(prefix)+len(enc) <= n {
+			return append(append(out, prefix...), enc...), true
+		}
+	}
+	return out, false
+}
+
+// appendLabels appends up to count dot-separated labels without exceeding
+// budget bytes, shortening or dropping labels as needed.
+func appendLabels(out []byte, count, budget int, opts *DomainOptions, rng *wordRNG) []byte {
+	lo, hi := opts.labelBounds()
+	start := len(out)
+	for i := range count {
+		used := len(out) - start
+		if i > 0 {
+			used++
+		}
+		// Leave at least one byte and a dot for every remaining label.
+		avail := budget - used - 2*(count-i-1)
+		if avail < 1 {
+			break
+		}
+		if i > 0 {
+			out = append(out, '.')
+		}
+		n := min(lo+int(uniformUint64n(uint64(hi-lo+1), rng)), avail)
+		if opts.IDN && uniformUint64n(2, rng) == 0 {
+			var ok bool
+			if out, ok = appendIDNLabel(out, n, rng); ok {
+				continue
+			}
+		}
+		out = appendLDHLabel(out, n, rng)
+	}
+	return out
+}
+
+// Hostname generates a random host name made of RFC 1123 labels without a
+// top-level suffix, such as "k3-fw9.x2a". A nil opts yields a single label.
+func (n network) Hostname(opts *DomainOptions) string {
+	if opts == nil {
+		opts = new(DomainOptions)
+	}
+	rng := n.r.source()
+	count := max(opts.Labels, 1)
+	return string(appendLabels(make([]byte, 0, 16*count), count, maxDomainLen, opts, rng))
+}
+
+// validSuffix reports whether s is a dot-separated sequence of RFC 1123
+// labels whose last label is not all digits, leaving room for at least one
+// more label within the 253-byte limit.
+// ref: https://datatracker.ietf.org/doc/html/rfc3696#section-2
+func validSuffix(s string) bool {
+	if len(s) > maxDomainLen-2 {
+		return false
+	}
+	for label := range strings.SplitSeq(s, ".") {
+		if len(label) == 0 || len(label) > maxLabelLen || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for i := range len(label) {
+			if strings.IndexByte(ldhInner, label[i]) < 0 {
+				return false
+			}
+		}
+	}
+	return !isDigits(s[strings.LastIndexByte(s, '.')+1:])
+}
+
+// Domain generates a random domain name of RFC 1123 labels followed by a
+// top-level suffix. The result never exceeds 253 bytes: labels are shortened
+// or dropped to fit. A nil opts yields one label and a public suffix.
+// It returns an empty string if opts.TLD is not a valid suffix: a sequence of
+// 1 to 63 byte labels of letters, digits and interior hyphens, not ending in
+// an all-numeric label and at most 251 bytes long.
+func (n network) Domain(opts *DomainOptions) string {
+	if opts == nil {
+		opts = new(DomainOptions)
+	}
+	tld, ok := opts.tld()
+	if !ok {
+		return ""
+	}
+	rng := n.r.source()
+	if tld == "" {
+		switch opts.TLDSource {
+		case ReservedTLD:
+			tld = reservedTLDs[uniformUint64n(uint64(len(reservedTLDs)), rng)]
+		default:
+			tld = publicSuffixes[uniformUint64n(uint64(len(publicSuffixes)), rng)]
+		}
+	}
+	count := max(opts.Labels, 1)
+	out := make([]byte, 0, 16*count+len(tld))
+	out = appendLabels(out, count, maxDomainLen-len(tld)-1, opts, rng)
+	if len(out) > 0 {
+		out = append(out, '.')
+	}
+	return string(append(out, tld...))
+}
+
+// Punycode parameters.
+// ref: https://datatracker.ietf.org/doc/html/rfc3492#section-5
+const (
+	punyBase        = 36
+	punyTMin        = 1
+	punyTMax        = 26
+	punySkew        = 38
+	punyDamp        = 700
+	punyInitialBias = 72
+	punyInitialN    = 128
+)
+
+func punyAdapt(delta, numPoints int, first bool) int {
+	if first {
+		delta /= punyDamp
+	} else {
+		delta /= 2
+	}
+	delta += delta / numPoints
+	k := 0
+	for delta > ((punyBase-punyTMin)*punyTMax)/2 {
+		delta /= punyBase - punyTMin
+		k += punyBase
+	}
+	return k + (punyBase-punyTMin+1)*delta/(delta+punySkew)
+}
+
+func punyDigit(d int) byte {
+	if d < 26 {
+		return byte('a' + d)
+	}
+	return byte('0' + d - 26)
+}
+
+// punycodeEncode encodes input with the Punycode algorithm of RFC 3492,
+// without the "xn--" prefix.
+func punycodeEncode(input []rune) string {
+	out := make([]byte, 0, 2*len(input))
+	for _, r := range input {
+		if r < utf8.RuneSelf {
+			out = append(out, byte(r))
+		}
+	}
+	basic := len(out)
+	handled := basic
+	if basic > 0 {
+		out = append(out, '-')
+	}
+	n, delta, bias := punyInitialN, 0, punyInitialBias
+	for handled < len(input) {
+		m := int(utf8.MaxRune) + 1
+		for _, r := range input {
+			if int(r) >= n && int(r) < m {
+				m = int(r)
+			}
+		}
+		delta += (m - n) * (handled + 1)
+		n = m
+		for _, r := range input {
+			if int(r) < n {
+				delta++
+			}
+			if int(r) != n {
+				continue
+			}
+			q := delta
+			for k := punyBase; ; k += punyBase {
+				t := min(max(k-bias, punyTMin), punyTMax)
+				if q < t {
+					break
+				}
+				out = append(out, punyDigit(t+(q-t)%(punyBase-t)))
+				q = (q - t) / (punyBase - t)
+			}
+			out = append(out, punyDigit(q))
+			bias = punyAdapt(delta, handled+1, handled == basic)
+			delta = 0
+			handled++
+		}
+		delta++
+		n++
+	}
+	return string(out)
+}
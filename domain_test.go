@@ -0,0 +1,88 @@
+package randomizer_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/colduction/randomizer"
+)
+
+var ldhLabel = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+func checkLabels(t *testing.T, name string, labels []string) {
+	t.Helper()
+	for _, l := range labels {
+		if !ldhLabel.MatchString(l) || len(l) > 63 {
+			t.Fatalf("%q has invalid label %q", name, l)
+		}
+		if strings.Contains(l, "--") && !strings.HasPrefix(l, "xn--") {
+			t.Fatalf("%q has label %q with reserved double hyphen", name, l)
+		}
+	}
+}
+
+func TestNetworkHostname(t *testing.T) {
+	for range 1000 {
+		h := randomizer.Network.Hostname(nil)
+		if strings.Contains(h, ".") || len(h) < 3 || len(h) > 12 {
+			t.Fatalf("Hostname(nil) = %q", h)
+		}
+		checkLabels(t, h, []string{h})
+	}
+	opts := &randomizer.DomainOptions{Labels: 3, MinLabelLen: 5, MaxLabelLen: 5}
+	h := randomizer.Network.Hostname(opts)
+	if len(h) != 17 || strings.Count(h, ".") != 2 {
+		t.Fatalf("Hostname with 3 labels of 5 bytes = %q", h)
+	}
+}
+
+func TestNetworkDomain(t *testing.T) {
+	opts := &randomizer.DomainOptions{Labels: 2, TLDSource: randomizer.ReservedTLD, IDN: true}
+	for range 1000 {
+		d := randomizer.Network.Domain(opts)
+		labels := strings.Split(d, ".")
+		switch labels[len(labels)-1] {
+		case "test", "example", "invalid", "localhost":
+		default:
+			t.Fatalf("Domain with ReservedTLD = %q", d)
+		}
+		if len(labels) != 3 {
+			t.Fatalf("Domain with 2 labels = %q", d)
+		}
+		checkLabels(t, d, labels)
+	}
+	if d := randomizer.Network.Domain(&randomizer.DomainOptions{TLD: ".co.uk"}); !strings.HasSuffix(d, ".co.uk") {
+		t.Fatalf("Domain with TLD co.uk = %q", d)
+	}
+	for _, tld := range []string{"-com", "com-", "a..b", "a_b", "123", "co.", strings.Repeat("a.", 126) + "a"} {
+		opts := &randomizer.DomainOptions{TLD: tld}
+		if d := randomizer.Network.Domain(opts); d != "" {
+			t.Fatalf("Domain with TLD %q = %q, want empty", tld, d)
+		}
+		if e := randomizer.Network.Email(&randomizer.EmailOptions{Domain: opts, EdgeCases: true}); e != "" {
+			t.Fatalf("Email with TLD %q = %q, want empty", tld, e)
+		}
+	}
+	if d := randomizer.Network.Domain(&randomizer.DomainOptions{TLD: "XN--P1AI"}); !strings.HasSuffix(d, ".xn--p1ai") {
+		t.Fatalf("Domain with TLD XN--P1AI = %q", d)
+	}
+}
+
+func TestNetworkDomainMaxLength(t *testing.T) {
+	opts := &randomizer.DomainOptions{Labels: 10, MinLabelLen: 63, MaxLabelLen: 63, IDN: true}
+	for range 200 {
+		d := randomizer.Network.Domain(opts)
+		if len(d) > 253 {
+			t.Fatalf("Domain length = %d, want at most 253", len(d))
+		}
+		checkLabels(t, d, strings.Split(d, "."))
+	}
+}
+
+func BenchmarkNetworkDomain(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		benchWordString = randomizer.Network.Domain(nil)
+	}
+}
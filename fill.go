@@ -0,0 +1,832 @@
+package randomizer
+
+import (
+	"errors"
+	"math"
+	"net"
+	"net/netip"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrInvalidFillTarget is returned when Fill is given something other than a
+// non-nil pointer.
+var ErrInvalidFillTarget = errors.New("randomizer: fill target must be a non-nil pointer")
+
+// ErrInvalidTag is wrapped by every TagError.
+var ErrInvalidTag = errors.New("randomizer: invalid rand tag")
+
+// TagError reports a rand struct tag that is malformed or does not suit the
+// type of its field.
+type TagError struct {
+	// Field is the path of the field, such as "Config.Servers[].Addr".
+	Field string
+	// Tag is the raw tag value.
+	Tag string
+	// Reason describes the problem.
+	Reason string
+}
+
+func (e *TagError) Error() string {
+	return "randomizer: invalid rand tag " + strconv.Quote(e.Tag) + " on " + e.Field + ": " + e.Reason
+}
+
+func (e *TagError) Unwrap() error { return ErrInvalidTag }
+
+// Defaults used by Fill when FillOptions leaves a field zero.
+const (
+	DefaultFillMinLen   = 1
+	DefaultFillMaxLen   = 8
+	DefaultFillMaxDepth = 8
+)
+
+// FillOptions configures FillWith and NewWith.
+type FillOptions struct {
+	// MinLen and MaxLen bound the length of strings, slices and maps that
+	// have no length in their tag. Zero values mean DefaultFillMinLen and
+	// DefaultFillMaxLen.
+	MinLen, MaxLen int
+	// MaxDepth bounds how many structs, pointers, slices, arrays and maps
+	// are entered; deeper values are left zero, which ends recursive types
+	// such as linked lists. Zero means DefaultFillMaxDepth.
+	MaxDepth int
+	// Rand drives every generated value, so that a fill replays under its
+	// seed; nil means a Rand with a random seed.
+	Rand *Rand
+}
+
+// Fill assigns random values to the value ptr points to, as FillWith with
+// default options.
+func Fill(ptr any) error {
+	return FillWith(ptr, nil)
+}
+
+// FillWith walks the value ptr points to and assigns random values to
+// booleans, numbers, strings, structs, pointers, slices, arrays and maps, as
+// well as netip.Addr, netip.Prefix, netip.AddrPort, net.IP,
+// net.HardwareAddr, time.Time and time.Duration. Unexported fields,
+// interfaces, channels and functions are left untouched.
+//
+// A struct field can select a generator with a rand tag made of an optional
+// kind followed by comma-separated parameters:
+//
+//	ID    string     `rand:"hex,len=16"`
+//	Addr  netip.Addr `rand:"ipv6,unicast=linklocal"`
+//	Count int        `rand:"int,min=1,max=10"`
+//	Cache []byte     `rand:"-"`
+//
+// String kinds, which also fill []byte fields, are hex (with "upper"),
+// decimal, octal, alpha, alnum, email, url and domain (with tld= and
+// labels=), hostname (with labels=), iban (with country=), isbn10, isbn13,
+// ean13, upca, vin, imei, luhn (with prefix=), and pattern= and regex=, which
+// take the rest of the tag as their argument. The lengths of hex, decimal,
+// octal, alpha, alnum and luhn strings are set with len=, or minlen= and
+// maxlen=; other kinds reject them, and without a kind they select alnum.
+//
+// Address kinds ip, ipv4 and ipv6 fill netip.Addr, net.IP and string fields.
+// They accept prefix= with a CIDR, ipv4 accepts category= (public, private,
+// shared, loopback, linklocal, documentation or multicast) and ipv6 accepts
+// unicast= (global, linklocal, sitelocal or uniquelocal) or multicast= (a
+// scope: interface, link, admin, site, org or global). The mac kind fills
+// net.HardwareAddr and string fields and accepts the local and multicast
+// flags.
+//
+// Numeric kinds int, uint and float take inclusive bounds min= and max=; the
+// kind may be omitted. The port kind fills integers with a port and accepts
+// range= (any, wellknown, registered, dynamic or ephemeral).
+//
+// On slices, arrays, maps and pointers the tag applies to the elements, or to
+// map values. A "-" tag skips the field.
+func FillWith(ptr any, opts *FillOptions) error {
+	v := reflect.ValueOf(ptr)
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		return ErrInvalidFillTarget
+	}
+	f := newFiller(opts)
+	return f.fill(v.Elem(), nil, v.Elem().Type().String(), 0)
+}
+
+// New returns a value of type T filled with random values as by Fill.
+func New[T any]() (T, error) {
+	return NewWith[T](nil)
+}
+
+// NewWith returns a value of type T filled with random values as by FillWith.
+func NewWith[T any](opts *FillOptions) (T, error) {
+	var v T
+	err := FillWith(&v, opts)
+	return v, err
+}
+
+type filler struct {
+	minLen, maxLen, maxDepth int
+	rng                      *wordRNG
+	network                  network
+	word                     word
+}
+
+func newFiller(opts *FillOptions) *filler {
+	f := &filler{minLen: DefaultFillMinLen, maxLen: DefaultFillMaxLen, maxDepth: DefaultFillMaxDepth}
+	var r *Rand
+	if opts != nil {
+		r = opts.Rand
+		if opts.MinLen > 0 {
+			f.minLen = opts.MinLen
+		}
+		if opts.MaxLen > 0 {
+			f.maxLen = opts.MaxLen
+		}
+		if opts.MaxDepth > 0 {
+			f.maxDepth = opts.MaxDepth
+		}
+	}
+	if r == nil {
+		r = NewRand(DefaultHashPool.Sum64())
+	}
+	f.rng, f.network, f.word = &r.rng, r.Network(), r.Word()
+	f.maxLen = max(f.maxLen, f.minLen)
+	return f
+}
+
+// length returns a random length in [lo, hi].
+func (f *filler) length(lo, hi int) int {
+	return lo + int(uniformUint64n(uint64(hi-lo)+1, f.rng))
+}
+
+var (
+	addrType         = reflect.TypeFor[netip.Addr]()
+	prefixType       = reflect.TypeFor[netip.Prefix]()
+	addrPortType     = reflect.TypeFor[netip.AddrPort]()
+	ipType           = reflect.TypeFor[net.IP]()
+	hardwareAddrType = reflect.TypeFor[net.HardwareAddr]()
+	timeType         = reflect.TypeFor[time.Time]()
+	durationType     = reflect.TypeFor[time.Duration]()
+)
+
+// Random times and durations fall within these bounds.
+var (
+	fillTimeMin     = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	fillTimeMax     = time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	fillDurationMax = 24 * time.Hour
+)
+
+func (f *filler) fill(v reflect.Value, tag *randTag, path string, depth int) error {
+	if tag != nil && tag.kind != "" {
+		switch {
+		case isTagTarget(v.Type()):
+			return f.tagged(v, tag, path)
+		case !isTagContainer(v.Kind()):
+			return tag.errorf(path, "unsupported field type "+v.Type().String())
+		}
+	}
+	switch v.Type() {
+	case addrType:
+		if f.rng.next64()&1 == 0 {
+			v.Set(reflect.ValueOf(f.network.Addr4()))
+		} else {
+			v.Set(reflect.ValueOf(f.network.Addr6()))
+		}
+		return nil
+	case prefixType:
+		if f.rng.next64()&1 == 0 {
+			v.Set(reflect.ValueOf(f.network.Prefix4(int(uniformUint64n(33, f.rng)))))
+		} else {
+			v.Set(reflect.ValueOf(f.network.Prefix6(int(uniformUint64n(129, f.rng)))))
+		}
+		return nil
+	case addrPortType:
+		if f.rng.next64()&1 == 0 {
+			v.Set(reflect.ValueOf(f.network.AddrPort4()))
+		} else {
+			v.Set(reflect.ValueOf(f.network.AddrPort6()))
+		}
+		return nil
+	case ipType:
+		if f.rng.next64()&1 == 0 {
+			v.SetBytes(f.network.IPv4Addr())
+		} else {
+			v.SetBytes(f.network.IPv6Addr())
+		}
+		return nil
+	case hardwareAddrType:
+		v.SetBytes(f.network.MACAddr(false, false))
+		return nil
+	case timeType:
+		span := uint64(fillTimeMax.Sub(fillTimeMin))
+		v.Set(reflect.ValueOf(fillTimeMin.Add(time.Duration(uniformUint64n(span, f.rng)))))
+		return nil
+	case durationType:
+		v.SetInt(int64(uniformUint64n(uint64(fillDurationMax), f.rng)))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		v.SetBool(f.rng.next64()&1 == 1)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(int64(f.rng.next64()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		v.SetUint(f.rng.next64())
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(f.rng.float64())
+	case reflect.Complex64, reflect.Complex128:
+		v.SetComplex(complex(f.rng.float64(), f.rng.float64()))
+	case reflect.String:
+		out := make([]byte, f.length(f.minLen, f.maxLen))
+		fillAlphabet(out, alphanumdict, f.rng)
+		v.SetString(string(out))
+	case reflect.Pointer:
+		if depth >= f.maxDepth {
+			return nil
+		}
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return f.fill(v.Elem(), tag, path, depth+1)
+	case reflect.Array:
+		if depth >= f.maxDepth {
+			return nil
+		}
+		for i := range v.Len() {
+			if err := f.fill(v.Index(i), tag, path+"[]", depth+1); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice:
+		if depth >= f.maxDepth {
+			return nil
+		}
+		n := f.length(f.minLen, f.maxLen)
+		s := reflect.MakeSlice(v.Type(), n, n)
+		for i := range s.Len() {
+			if err := f.fill(s.Index(i), tag, path+"[]", depth+1); err != nil {
+				return err
+			}
+		}
+		v.Set(s)
+	case reflect.Map:
+		if depth >= f.maxDepth {
+			return nil
+		}
+		t := v.Type()
+		n := f.length(f.minLen, f.maxLen)
+		m := reflect.MakeMapWithSize(t, n)
+		for range n {
+			key, val := reflect.New(t.Key()).Elem(), reflect.New(t.Elem()).Elem()
+			if err := f.fill(key, nil, path+"[key]", depth+1); err != nil {
+				return err
+			}
+			if err := f.fill(val, tag, path+"[]", depth+1); err != nil {
+				return err
+			}
+			m.SetMapIndex(key, val)
+		}
+		v.Set(m)
+	case reflect.Struct:
+		if depth >= f.maxDepth {
+			return nil
+		}
+		info := loadStructInfo(v.Type())
+		for _, fi := range info {
+			if fi.skip {
+				continue
+			}
+			if fi.err != nil {
+				err := *fi.err
+				err.Field = path + "." + err.Field
+				return &err
+			}
+			if err := f.fill(v.Field(fi.index), fi.tag, path+"."+fi.name, depth+1); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// isTagContainer reports whether a tag on a value of kind k applies to its
+// elements.
+func isTagContainer(k reflect.Kind) bool {
+	switch k {
+	case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+		return true
+	}
+	return false
+}
+
+// isTagTarget reports whether a tagged value of type t is generated directly
+// rather than element by element.
+func isTagTarget(t reflect.Type) bool {
+	switch t {
+	case addrType, ipType, hardwareAddrType:
+		return true
+	}
+	switch t.Kind() {
+	case reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	case reflect.Slice:
+		return t.Elem().Kind() == reflect.Uint8
+	}
+	return false
+}
+
+// fieldInfo caches the parsed tag of an exported struct field.
+type fieldInfo struct {
+	index int
+	name  string
+	skip  bool
+	tag   *randTag
+	err   *TagError
+}
+
+// structInfos caches a []fieldInfo per struct reflect.Type.
+var structInfos sync.Map
+
+func loadStructInfo(t reflect.Type) []fieldInfo {
+	if info, ok := structInfos.Load(t); ok {
+		return info.([]fieldInfo)
+	}
+	info := make([]fieldInfo, 0, t.NumField())
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		fi := fieldInfo{index: i, name: sf.Name}
+		raw, ok := sf.Tag.Lookup("rand")
+		switch {
+		case raw == "-":
+			fi.skip = true
+		case ok:
+			fi.tag, fi.err = parseRandTag(sf.Name, raw)
+		}
+		info = append(info, fi)
+	}
+	actual, _ := structInfos.LoadOrStore(t, info)
+	return actual.([]fieldInfo)
+}
+
+// randTag is a parsed rand struct tag.
+type randTag struct {
+	raw    string
+	kind   string
+	params map[string]string
+}
+
+// restParams take the remainder of the tag as their value, commas included.
+var restParams = [...]string{"pattern", "regex"}
+
+var tagKinds = map[string]bool{
+	"hex": true, "decimal": true, "octal": true, "alpha": true, "alnum": true,
+	"email": true, "url": true, "domain": true, "hostname": true,
+	"iban": true, "isbn10": true, "isbn13": true, "ean13": true, "upca": true,
+	"vin": true, "imei": true, "luhn": true, "pattern": true, "regex": true,
+	"ip": true, "ipv4": true, "ipv6": true, "mac": true,
+	"int": true, "uint": true, "float": true, "port": true,
+}
+
+func parseRandTag(field, raw string) (*randTag, *TagError) {
+	t := &randTag{raw: raw, params: map[string]string{}}
+	rest := raw
+	for rest != "" {
+		var item string
+		item, rest, _ = strings.Cut(rest, ",")
+		key, val, hasVal := strings.Cut(item, "=")
+		key = strings.TrimSpace(key)
+		for _, p := range restParams {
+			if key == p && hasVal {
+				if rest != "" {
+					val += "," + rest
+				}
+				rest = ""
+			}
+		}
+		if key == "" {
+			continue
+		}
+		if hasVal {
+			t.params[key] = val
+		} else if t.kind == "" && len(t.params) == 0 && tagKinds[key] {
+			t.kind = key
+		} else {
+			t.params[key] = ""
+		}
+	}
+	// pattern= and regex= imply their kind; bounds alone imply a number and
+	// lengths alone an alphanumeric string.
+	for _, p := range restParams {
+		if _, ok := t.params[p]; ok && (t.kind == "" || t.kind == p) {
+			t.kind = p
+		}
+	}
+	if t.kind == "" {
+		switch {
+		case t.has("min"), t.has("max"):
+			t.kind = "number"
+		case t.has("len"), t.has("minlen"), t.has("maxlen"):
+			t.kind = "alnum"
+		}
+	}
+	if t.kind == "" && len(t.params) > 0 {
+		return nil, &TagError{Field: field, Tag: raw, Reason: "unknown kind"}
+	}
+	return t, nil
+}
+
+func (t *randTag) errorf(field, reason string) *TagError {
+	return &TagError{Field: field, Tag: t.raw, Reason: reason}
+}
+
+func (t *randTag) has(key string) bool {
+	_, ok := t.params[key]
+	return ok
+}
+
+// intParam returns the integer parameter key, or def if it is absent.
+func (t *randTag) intParam(key string, def int) (int, bool) {
+	s, ok := t.params[key]
+	if !ok {
+		return def, true
+	}
+	n, err := strconv.Atoi(s)
+	return n, err == nil && n >= 0
+}
+
+// tagged assigns a value produced by the generator named in tag.
+func (f *filler) tagged(v reflect.Value, tag *randTag, path string) error {
+	switch tag.kind {
+	case "ip", "ipv4", "ipv6":
+		a, reason := f.tagAddr(tag)
+		if reason != "" {
+			return tag.errorf(path, reason)
+		}
+		return setAddr(v, a, tag, path)
+	case "mac":
+		opts := MACOptions{Local: tag.has("local"), Multicast: tag.has("multicast")}
+		mac, _ := f.network.MAC(&opts)
+		switch {
+		case v.Type() == hardwareAddrType:
+			v.SetBytes(mac)
+		case v.Kind() == reflect.String:
+			v.SetString(mac.String())
+		default:
+			return tag.errorf(path, "mac needs a net.HardwareAddr or string field")
+		}
+		return nil
+	case "int", "uint", "float", "number", "port":
+		return f.tagNumber(v, tag, path)
+	}
+
+	s, reason := f.tagString(tag)
+	if reason != "" {
+		return tag.errorf(path, reason)
+	}
+	switch {
+	case v.Kind() == reflect.String:
+		v.SetString(s)
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8:
+		v.SetBytes([]byte(s))
+	default:
+		return tag.errorf(path, tag.kind+" needs a string or []byte field")
+	}
+	return nil
+}
+
+func setAddr(v reflect.Value, a netip.Addr, tag *randTag, path string) error {
+	switch {
+	case v.Type() == addrType:
+		v.Set(reflect.ValueOf(a))
+	case v.Type() == ipType:
+		v.SetBytes(a.AsSlice())
+	case v.Kind() == reflect.String:
+		v.SetString(a.String())
+	default:
+		return tag.errorf(path, tag.kind+" needs a netip.Addr, net.IP or string field")
+	}
+	return nil
+}
+
+var (
+	ipv4CategoryNames = map[string]IPv4Category{
+		"public": IPv4Public, "private": IPv4Private, "shared": IPv4Shared, "cgnat": IPv4Shared,
+		"loopback": IPv4Loopback, "linklocal": IPv4LinkLocal, "documentation": IPv4Documentation,
+		"multicast": IPv4Multicast,
+	}
+	unicastTypeNames = map[string]UnicastType{
+		"global": GlobalType, "linklocal": LinkLocalType, "sitelocal": SiteLocalType,
+		"uniquelocal": UniqueLocalType, "private": PrivateType,
+	}
+	multicastScopeNames = map[string]MulticastScope{
+		"interface": InterfaceLocalScope, "link": LinkLocalScope, "admin": AdminLocalScope,
+		"site": SiteLocalScope, "org": OrgLocalScope, "global": GlobalScope,
+	}
+	portRangeNames = map[string]PortRange{
+		"any": AnyPorts, "wellknown": WellKnownPorts, "system": SystemPorts,
+		"registered": RegisteredPorts, "user": UserPorts, "dynamic": DynamicPorts,
+		"ephemeral": EphemeralPorts, "linux": LinuxEphemeralPorts,
+	}
+)
+
+// tagAddr generates an address for an ip, ipv4 or ipv6 tag, or returns the
+// reason the tag is invalid.
+func (f *filler) tagAddr(tag *randTag) (netip.Addr, string) {
+	if s, ok := tag.params["prefix"]; ok {
+		p, err := netip.ParsePrefix(s)
+		if err != nil || (tag.kind == "ipv4" && !p.Addr().Is4()) || (tag.kind == "ipv6" && !p.Addr().Is6()) {
+			return netip.Addr{}, "invalid prefix " + strconv.Quote(s)
+		}
+		a, err := addrInPrefix(p, nil, f.rng)
+		if err != nil {
+			return netip.Addr{}, err.Error()
+		}
+		return a, ""
+	}
+	kind := tag.kind
+	if kind == "ip" {
+		kind = "ipv4"
+		if f.rng.next64()&1 == 1 {
+			kind = "ipv6"
+		}
+	}
+	if kind == "ipv4" {
+		s, ok := tag.params["category"]
+		if !ok {
+			return f.network.Addr4(), ""
+		}
+		c, ok := ipv4CategoryNames[s]
+		if !ok {
+			return netip.Addr{}, "unknown IPv4 category " + strconv.Quote(s)
+		}
+		return f.network.Addr4Category(c), ""
+	}
+	if s, ok := tag.params["unicast"]; ok {
+		t, ok := unicastTypeNames[s]
+		if !ok {
+			return netip.Addr{}, "unknown unicast type " + strconv.Quote(s)
+		}
+		return f.network.Addr6Unicast(t), ""
+	}
+	if s, ok := tag.params["multicast"]; ok {
+		scope, ok := multicastScopeNames[s]
+		if !ok {
+			return netip.Addr{}, "unknown multicast scope " + strconv.Quote(s)
+		}
+		return f.network.Addr6Multicast(scope), ""
+	}
+	return f.network.Addr6(), ""
+}
+
+// tagString generates a string for a string kind, or returns the reason the
+// tag is invalid.
+func (f *filler) tagString(tag *randTag) (string, string) {
+	lo, okLo := tag.intParam("minlen", f.minLen)
+	hi, okHi := tag.intParam("maxlen", max(f.maxLen, lo))
+	if tag.has("len") {
+		lo, okLo = tag.intParam("len", 0)
+		hi, okHi = lo, okLo
+	}
+	if !okLo || !okHi || lo > hi {
+		return "", "invalid length"
+	}
+	if !lengthKinds[tag.kind] && (tag.has("len") || tag.has("minlen") || tag.has("maxlen")) {
+		return "", tag.kind + " does not take a length"
+	}
+	n := f.length(lo, hi)
+
+	switch tag.kind {
+	case "hex":
+		return f.word.Hex(n, tag.has("upper")), ""
+	case "decimal":
+		return f.word.Decimal(n), ""
+	case "octal":
+		return f.word.Octal(n), ""
+	case "alpha":
+		out := make([]byte, n)
+		fillAlphabet(out, alphadict, f.rng)
+		return string(out), ""
+	case "alnum":
+		out := make([]byte, n)
+		fillAlphabet(out, alphanumdict, f.rng)
+		return string(out), ""
+	case "email", "url", "domain":
+		domain, reason := tagDomain(tag)
+		if reason != "" {
+			return "", reason
+		}
+		var s string
+		switch tag.kind {
+		case "email":
+			s = f.network.Email(&EmailOptions{Domain: domain})
+		case "url":
+			s = f.network.URL(&URLOptions{Domain: domain})
+		default:
+			s = f.network.Domain(domain)
+		}
+		if s == "" {
+			return "", "invalid tld"
+		}
+		return s, ""
+	case "hostname":
+		if tag.has("tld") {
+			return "", "hostname does not take a tld"
+		}
+		domain, reason := tagDomain(tag)
+		if reason != "" {
+			return "", reason
+		}
+		return f.network.Hostname(domain), ""
+	case "iban":
+		country := tag.params["country"]
+		s := f.word.IBAN(country)
+		if s == "" {
+			return "", "unsupported IBAN country " + strconv.Quote(country)
+		}
+		return s, ""
+	case "isbn10":
+		return f.word.ISBN10(), ""
+	case "isbn13":
+		return f.word.ISBN13(), ""
+	case "ean13":
+		return f.word.EAN13(), ""
+	case "upca":
+		return f.word.UPCA(), ""
+	case "vin":
+		return f.word.VIN(), ""
+	case "imei":
+		return f.word.IMEI(), ""
+	case "luhn":
+		if !tag.has("len") && !tag.has("minlen") && !tag.has("maxlen") {
+			n = 16
+		}
+		if n < 2 {
+			return "", "invalid length"
+		}
+		prefix := tag.params["prefix"]
+		s := f.word.Luhn(n, prefix)
+		if s == "" {
+			return "", "invalid luhn prefix " + strconv.Quote(prefix) + " for length " + strconv.Itoa(n)
+		}
+		return s, ""
+	case "pattern":
+		return f.word.Pattern(tag.params["pattern"]), ""
+	case "regex":
+		s, err := f.word.FromRegex(tag.params["regex"])
+		if err != nil {
+			return "", err.Error()
+		}
+		return s, ""
+	}
+	return "", "unknown kind " + strconv.Quote(tag.kind)
+}
+
+// lengthKinds are the string kinds that take len=, minlen= and maxlen=.
+var lengthKinds = map[string]bool{
+	"hex": true, "decimal": true, "octal": true, "alpha": true, "alnum": true, "luhn": true,
+}
+
+// tagDomain returns the DomainOptions set by the tld= and labels= parameters
+// of tag, or the reason they are invalid.
+func tagDomain(tag *randTag) (*DomainOptions, string) {
+	labels, ok := tag.intParam("labels", 0)
+	if !ok {
+		return nil, "invalid labels"
+	}
+	return &DomainOptions{TLD: tag.params["tld"], Labels: labels}, ""
+}
+
+// tagNumber assigns a number within the inclusive min and max bounds of tag.
+func (f *filler) tagNumber(v reflect.Value, tag *randTag, path string) error {
+	minS, hasMin := tag.params["min"]
+	maxS, hasMax := tag.params["max"]
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if tag.kind != "int" && tag.kind != "number" && tag.kind != "port" {
+			break
+		}
+		bits := v.Type().Bits()
+		lo, hi := int64(-1)<<(bits-1), int64(uint64(1)<<(bits-1)-1)
+		if tag.kind == "port" {
+			p, reason := f.tagPort(tag)
+			if reason != "" {
+				return tag.errorf(path, reason)
+			}
+			if bits <= 16 && uint64(p) > uint64(hi) {
+				return tag.errorf(path, "port does not fit in "+v.Type().String())
+			}
+			v.SetInt(int64(p))
+			return nil
+		}
+		var err error
+		if hasMin {
+			if lo, err = strconv.ParseInt(minS, 0, bits); err != nil {
+				return tag.errorf(path, "invalid min")
+			}
+		}
+		if hasMax {
+			if hi, err = strconv.ParseInt(maxS, 0, bits); err != nil {
+				return tag.errorf(path, "invalid max")
+			}
+		}
+		if lo > hi {
+			return tag.errorf(path, "min is greater than max")
+		}
+		span := uint64(hi) - uint64(lo)
+		if span == math.MaxUint64 {
+			v.SetInt(int64(f.rng.next64()))
+		} else {
+			v.SetInt(lo + int64(uniformUint64n(span+1, f.rng)))
+		}
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if tag.kind != "uint" && tag.kind != "number" && tag.kind != "port" {
+			break
+		}
+		bits := v.Type().Bits()
+		lo, hi := uint64(0), uint64(math.MaxUint64)>>(64-bits)
+		if tag.kind == "port" {
+			p, reason := f.tagPort(tag)
+			if reason != "" {
+				return tag.errorf(path, reason)
+			}
+			if uint64(p) > hi {
+				return tag.errorf(path, "port does not fit in "+v.Type().String())
+			}
+			v.SetUint(uint64(p))
+			return nil
+		}
+		var err error
+		if hasMin {
+			if lo, err = strconv.ParseUint(minS, 0, bits); err != nil {
+				return tag.errorf(path, "invalid min")
+			}
+		}
+		if hasMax {
+			if hi, err = strconv.ParseUint(maxS, 0, bits); err != nil {
+				return tag.errorf(path, "invalid max")
+			}
+		}
+		if lo > hi {
+			return tag.errorf(path, "min is greater than max")
+		}
+		if hi-lo == math.MaxUint64 {
+			v.SetUint(f.rng.next64())
+		} else {
+			v.SetUint(lo + uniformUint64n(hi-lo+1, f.rng))
+		}
+		return nil
+	case reflect.Float32, reflect.Float64:
+		if tag.kind != "float" && tag.kind != "number" {
+			break
+		}
+		bits := v.Type().Bits()
+		lo, hi := 0.0, 1.0
+		var err error
+		if hasMin {
+			if lo, err = strconv.ParseFloat(minS, bits); err != nil {
+				return tag.errorf(path, "invalid min")
+			}
+		}
+		if hasMax {
+			if hi, err = strconv.ParseFloat(maxS, bits); err != nil {
+				return tag.errorf(path, "invalid max")
+			}
+		}
+		if !(lo <= hi) || math.IsInf(hi-lo, 0) {
+			return tag.errorf(path, "invalid bounds")
+		}
+		v.SetFloat(min(lo+(hi-lo)*f.rng.float64(), hi))
+		return nil
+	case reflect.String:
+		if tag.kind == "port" {
+			p, reason := f.tagPort(tag)
+			if reason != "" {
+				return tag.errorf(path, reason)
+			}
+			v.SetString(strconv.FormatUint(uint64(p), 10))
+			return nil
+		}
+	}
+	return tag.errorf(path, tag.kind+" does not suit a "+v.Type().String()+" field")
+}
+
+func (f *filler) tagPort(tag *randTag) (uint16, string) {
+	var opts PortOptions
+	if s, ok := tag.params["range"]; ok {
+		r, ok := portRangeNames[s]
+		if !ok {
+			return 0, "unknown port range " + strconv.Quote(s)
+		}
+		opts.Range = r
+	}
+	p, err := pickPort(&opts, f.rng)
+	if err != nil {
+		return 0, err.Error()
+	}
+	return p, ""
+}
@@ -0,0 +1,259 @@
+package randomizer_test
+
+import (
+	"errors"
+	"net"
+	"net/netip"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/colduction/randomizer"
+)
+
+type fillServer struct {
+	ID         string           `rand:"hex,len=16"`
+	Name       string           `rand:"alpha,minlen=3,maxlen=5"`
+	Addr       netip.Addr       `rand:"ipv6,unicast=linklocal"`
+	Gateway    net.IP           `rand:"ipv4,category=private"`
+	Subnet     string           `rand:"ip,prefix=10.1.0.0/16"`
+	MAC        net.HardwareAddr `rand:"mac,local"`
+	Port       uint16           `rand:"port,range=dynamic"`
+	Weight     int              `rand:"int,min=1,max=10"`
+	Ratio      float64          `rand:"min=0.5,max=0.75"`
+	Code       string           `rand:"regex=[A-Z]{2,3}-[0-9]{4}"`
+	Email      string           `rand:"email,tld=test"`
+	Tags       []string         `rand:"decimal,len=4"`
+	Token      string           `rand:"len=5"`
+	Host       string           `rand:"hostname,labels=3"`
+	Labels     map[string]uint8 `rand:"uint,max=3"`
+	Secret     []byte           `rand:"-"`
+	Peers      [2]*netip.Addr   `rand:"ipv4,category=documentation"`
+	Created    time.Time
+	Timeout    time.Duration
+	Enabled    bool
+	Raw        []byte
+	Nested     fillNested
+	unexported int
+}
+
+type fillNested struct {
+	Count  int32
+	Scores []float32
+}
+
+type fillNode struct {
+	Value int
+	Next  *fillNode
+}
+
+var (
+	hex16       = regexp.MustCompile(`^[0-9a-f]{16}$`)
+	alpha3to5   = regexp.MustCompile(`^[a-zA-Z]{3,5}$`)
+	fillCodeRe  = regexp.MustCompile(`^[A-Z]{2,3}-[0-9]{4}$`)
+	decimal4    = regexp.MustCompile(`^[0-9]{4}$`)
+	alnum5      = regexp.MustCompile(`^[a-zA-Z0-9]{5}$`)
+	subnetIn    = netip.MustParsePrefix("10.1.0.0/16")
+	linkLocal   = netip.MustParsePrefix("fe80::/10")
+	privateNets = []string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16"}
+)
+
+func TestFill(t *testing.T) {
+	for range 200 {
+		var s fillServer
+		if err := randomizer.Fill(&s); err != nil {
+			t.Fatal(err)
+		}
+		if !hex16.MatchString(s.ID) || !alpha3to5.MatchString(s.Name) || !fillCodeRe.MatchString(s.Code) || !alnum5.MatchString(s.Token) {
+			t.Fatalf("bad strings %q %q %q %q", s.ID, s.Name, s.Code, s.Token)
+		}
+		if strings.Count(s.Host, ".") != 2 {
+			t.Fatalf("Host = %q, want three labels", s.Host)
+		}
+		if !linkLocal.Contains(s.Addr) {
+			t.Fatalf("Addr = %v", s.Addr)
+		}
+		gw, _ := netip.AddrFromSlice(s.Gateway)
+		if !inAnyPrefix(gw.Unmap(), privateNets) {
+			t.Fatalf("Gateway = %v", s.Gateway)
+		}
+		if a, err := netip.ParseAddr(s.Subnet); err != nil || !subnetIn.Contains(a) {
+			t.Fatalf("Subnet = %q", s.Subnet)
+		}
+		if len(s.MAC) != 6 || s.MAC[0]&0x03 != 0x02 {
+			t.Fatalf("MAC = %v", s.MAC)
+		}
+		if s.Port < 49152 || s.Weight < 1 || s.Weight > 10 || s.Ratio < 0.5 || s.Ratio > 0.75 {
+			t.Fatalf("bad numbers %d %d %v", s.Port, s.Weight, s.Ratio)
+		}
+		if len(s.Tags) < 1 || len(s.Tags) > 8 || len(s.Labels) < 1 || len(s.Labels) > 8 {
+			t.Fatalf("collection sizes %d %d", len(s.Tags), len(s.Labels))
+		}
+		for _, tag := range s.Tags {
+			if !decimal4.MatchString(tag) {
+				t.Fatalf("Tags element %q", tag)
+			}
+		}
+		for _, v := range s.Labels {
+			if v > 3 {
+				t.Fatalf("Labels value %d", v)
+			}
+		}
+		for _, p := range s.Peers {
+			if p == nil || !p.Is4() {
+				t.Fatalf("Peers element %v", p)
+			}
+		}
+		if s.Secret != nil || s.unexported != 0 {
+			t.Fatal("skipped fields were filled")
+		}
+		if s.Created.Year() < 2000 || s.Created.Year() >= 2030 || s.Timeout < 0 || s.Timeout >= 24*time.Hour {
+			t.Fatalf("Created = %v, Timeout = %v", s.Created, s.Timeout)
+		}
+		if len(s.Raw) == 0 || len(s.Nested.Scores) == 0 {
+			t.Fatal("untagged slices left empty")
+		}
+	}
+}
+
+func TestFillOptions(t *testing.T) {
+	opts := &randomizer.FillOptions{MinLen: 3, MaxLen: 3, MaxDepth: 4}
+	v, err := randomizer.NewWith[struct {
+		List []int
+		Name string
+		Head *fillNode
+	}](opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(v.List) != 3 || len(v.Name) != 3 {
+		t.Fatalf("sizes %d %d, want 3", len(v.List), len(v.Name))
+	}
+	// The outer struct and each pointer and node count toward the depth.
+	depth := 0
+	for n := v.Head; n != nil; n = n.Next {
+		depth++
+	}
+	if depth != 2 {
+		t.Fatalf("list depth %d, want 2", depth)
+	}
+
+	n, err := randomizer.New[fillNode]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	depth = 0
+	for p := &n; p != nil; p = p.Next {
+		depth++
+	}
+	if depth == 0 || depth > randomizer.DefaultFillMaxDepth {
+		t.Fatalf("list depth %d", depth)
+	}
+}
+
+func TestFillRand(t *testing.T) {
+	fill := func(seed uint64) fillServer {
+		var s fillServer
+		if err := randomizer.FillWith(&s, &randomizer.FillOptions{Rand: randomizer.NewRand(seed)}); err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+	if a, b := fill(11), fill(11); !reflect.DeepEqual(a, b) {
+		t.Fatalf("fills with equal seeds diverged:\n%+v\n%+v", a, b)
+	}
+	if a, b := fill(11), fill(12); reflect.DeepEqual(a, b) {
+		t.Fatal("fills with different seeds are equal")
+	}
+}
+
+func TestFillErrors(t *testing.T) {
+	if err := randomizer.Fill(nil); !errors.Is(err, randomizer.ErrInvalidFillTarget) {
+		t.Errorf("Fill(nil) error = %v", err)
+	}
+	var x int
+	if err := randomizer.Fill(x); !errors.Is(err, randomizer.ErrInvalidFillTarget) {
+		t.Errorf("Fill(int) error = %v", err)
+	}
+
+	cases := []any{
+		&struct {
+			A int `rand:"int,min=10,max=1"`
+		}{},
+		&struct {
+			A uint8 `rand:"uint,max=300"`
+		}{},
+		&struct {
+			A bool `rand:"hex"`
+		}{},
+		&struct {
+			A int `rand:"hex"`
+		}{},
+		&struct {
+			A string `rand:"bogus"`
+		}{},
+		&struct {
+			A netip.Addr `rand:"ipv6,unicast=nowhere"`
+		}{},
+		&struct {
+			A string `rand:"hex,len=x"`
+		}{},
+		&struct {
+			A int8 `rand:"port"`
+		}{},
+		&struct {
+			A string `rand:"email,tld=-com"`
+		}{},
+		&struct {
+			A string `rand:"iban,country=XX"`
+		}{},
+		&struct {
+			A string `rand:"luhn,len=4,prefix=12345"`
+		}{},
+		&struct {
+			A string `rand:"hostname,tld=com"`
+		}{},
+		&struct {
+			A string `rand:"domain,labels=x"`
+		}{},
+		&struct {
+			A string `rand:"email,len=10"`
+		}{},
+	}
+	for _, c := range cases {
+		err := randomizer.Fill(c)
+		var te *randomizer.TagError
+		if !errors.As(err, &te) || !errors.Is(err, randomizer.ErrInvalidTag) {
+			t.Errorf("Fill(%T) error = %v, want TagError", c, err)
+			continue
+		}
+		if te.Field == "" || te.Tag == "" {
+			t.Errorf("TagError %+v lacks context", te)
+		}
+	}
+}
+
+func TestFillPortString(t *testing.T) {
+	v, err := randomizer.New[struct {
+		P string `rand:"port,range=wellknown"`
+	}]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p, err := strconv.Atoi(v.P); err != nil || p > 1023 {
+		t.Fatalf("P = %q", v.P)
+	}
+}
+
+func BenchmarkFill(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		var s fillServer
+		if err := randomizer.Fill(&s); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,259 @@
+package randomizer
+
+import (
+	"errors"
+	"maps"
+	"math"
+	"net/netip"
+	"slices"
+	"time"
+)
+
+// ErrInvalidFlowOptions is returned when flow options have negative, NaN or
+// infinite sizes or weights, a single host, or no protocol with a positive
+// weight.
+var ErrInvalidFlowOptions = errors.New("randomizer: invalid flow options")
+
+// Flow is a unidirectional traffic flow record keyed by its 5-tuple. For ICMP
+// flows the ports are zero except DstPort, which holds type<<8|code as in
+// NetFlow.
+type Flow struct {
+	SrcAddr  netip.Addr `json:"src_addr"`
+	DstAddr  netip.Addr `json:"dst_addr"`
+	SrcPort  uint16     `json:"src_port"`
+	DstPort  uint16     `json:"dst_port"`
+	Protocol IPProtocol `json:"protocol"`
+	Packets  uint64     `json:"packets"`
+	Bytes    uint64     `json:"bytes"`
+	Start    time.Time  `json:"start"`
+	End      time.Time  `json:"end"`
+}
+
+// FlowOptions configures Network.Flows. Zero fields take the documented defaults.
+type FlowOptions struct {
+	// HostPrefix is the address block hosts are drawn from; the zero value
+	// draws public IPv4 addresses.
+	HostPrefix netip.Prefix
+	// Hosts is the number of distinct hosts taking part, at least two; zero
+	// means 1000. It is capped to the size of HostPrefix.
+	Hosts int
+	// ZipfExponent is the exponent s of the Zipf distribution over host and
+	// service popularity: the k-th most popular host is chosen with weight
+	// 1/k^s. Zero means 1; larger values concentrate traffic on fewer hot hosts.
+	ZipfExponent float64
+	// Protocols weights the protocol mix. Nil means 80% TCP, 17% UDP and 3%
+	// ICMP; ICMP on IPv6 hosts is reported as ICMPv6.
+	Protocols map[IPProtocol]float64
+	// MeanPackets is the mean number of packets per flow, at least one; zero
+	// means 20. Counts are geometrically distributed.
+	MeanPackets float64
+	// MinPacketSize and MaxPacketSize bound the average packet size in bytes
+	// of each flow; zero values mean 40 and 1500.
+	MinPacketSize, MaxPacketSize int
+	// MeanDuration is the mean duration of multi-packet flows, which is
+	// exponentially distributed; zero means 5 seconds.
+	MeanDuration time.Duration
+	// Start and Window bound flow start times to [Start, Start+Window). A
+	// zero Start means the current time and a zero Window one minute.
+	Start  time.Time
+	Window time.Duration
+}
+
+// servicePorts lists destination ports from the most to the least popular.
+var servicePorts = map[IPProtocol][]uint16{
+	ProtoTCP: {443, 80, 22, 25, 993, 8080, 3306, 5432, 3389, 445, 21, 110},
+	ProtoUDP: {53, 443, 123, 161, 514, 5353, 67, 500, 4500, 1194, 69, 137},
+}
+
+// defaultProtocolMix is used when FlowOptions.Protocols is nil.
+var defaultProtocolMix = map[IPProtocol]float64{ProtoTCP: 0.80, ProtoUDP: 0.17, ProtoICMP: 0.03}
+
+// weightedTable selects indices in proportion to their weights.
+type weightedTable struct {
+	cum []float64
+}
+
+func newWeightedTable(weights []float64) weightedTable {
+	t := weightedTable{cum: make([]float64, len(weights))}
+	sum := 0.0
+	for i, w := range weights {
+		sum += w
+		t.cum[i] = sum
+	}
+	return t
+}
+
+// zipfTable weights ranks 1..n by 1/k^s.
+func zipfTable(n int, s float64) weightedTable {
+	weights := make([]float64, n)
+	for k := range weights {
+		weights[k] = math.Pow(float64(k+1), -s)
+	}
+	return newWeightedTable(weights)
+}
+
+func (t *weightedTable) pick(rng *wordRNG) int {
+	v := rng.float64() * t.cum[len(t.cum)-1]
+	i, _ := slices.BinarySearch(t.cum, v)
+	if i < len(t.cum) && t.cum[i] == v {
+		i++
+	}
+	return min(i, len(t.cum)-1)
+}
+
+// expRand returns an exponentially distributed value with the given mean.
+func expRand(mean float64, rng *wordRNG) float64 {
+	return -mean * math.Log(1-rng.float64())
+}
+
+// flowHosts returns up to n distinct addresses from p, or public IPv4
+// addresses if p is the zero Prefix.
+func flowHosts(p netip.Prefix, n int, rng *wordRNG) ([]netip.Addr, error) {
+	if p.IsValid() {
+		p = p.Masked()
+		if hostBits := p.Addr().BitLen() - p.Bits(); hostBits < 32 {
+			n = min(n, 1<<hostBits)
+		}
+	}
+	hosts := make([]netip.Addr, 0, n)
+	seen := make(map[netip.Addr]struct{}, n)
+	for len(hosts) < n {
+		var a netip.Addr
+		if p.IsValid() {
+			var err error
+			if a, err = addrInPrefix(p, nil, rng); err != nil {
+				return nil, err
+			}
+		} else {
+			a = ipv4Categories[IPv4Public].pick(rng)
+		}
+		if _, ok := seen[a]; !ok {
+			seen[a] = struct{}{}
+			hosts = append(hosts, a)
+		}
+	}
+	return hosts, nil
+}
+
+// Flows generates n flow records sorted by start time. Sources and
+// destinations are drawn from a pool of hosts with Zipf-distributed
+// popularity, so a few hot hosts carry most flows; TCP and UDP destination
+// ports follow the same distribution over common service ports while source
+// ports are dynamic. Times are truncated to milliseconds. A nil opts uses the
+// defaults of FlowOptions.
+func (nw network) Flows(n int, opts *FlowOptions) ([]Flow, error) {
+	if opts == nil {
+		opts = new(FlowOptions)
+	}
+	if n < 0 || opts.Hosts < 0 || opts.Hosts == 1 || !(opts.ZipfExponent >= 0) || !(opts.MeanPackets >= 0) ||
+		math.IsInf(opts.ZipfExponent, 0) || math.IsInf(opts.MeanPackets, 0) ||
+		opts.MinPacketSize < 0 || opts.MaxPacketSize < 0 || opts.MeanDuration < 0 || opts.Window < 0 {
+		return nil, ErrInvalidFlowOptions
+	}
+
+	mix := opts.Protocols
+	if mix == nil {
+		mix = defaultProtocolMix
+	}
+	var protos []IPProtocol
+	var weights []float64
+	for _, p := range slices.Sorted(maps.Keys(mix)) {
+		w := mix[p]
+		if w < 0 || math.IsNaN(w) || math.IsInf(w, 0) {
+			return nil, ErrInvalidFlowOptions
+		}
+		if w > 0 {
+			protos, weights = append(protos, p), append(weights, w)
+		}
+	}
+	if len(protos) == 0 {
+		return nil, ErrInvalidFlowOptions
+	}
+	protoTable := newWeightedTable(weights)
+
+	rng := nw.r.source()
+	numHosts := opts.Hosts
+	if numHosts == 0 {
+		numHosts = 1000
+	}
+	hosts, err := flowHosts(opts.HostPrefix, numHosts, rng)
+	if err != nil {
+		return nil, err
+	}
+	if len(hosts) < 2 {
+		return nil, ErrPrefixExhausted
+	}
+
+	s := opts.ZipfExponent
+	if s == 0 {
+		s = 1
+	}
+	hostTable := zipfTable(len(hosts), s)
+	portTables := make(map[IPProtocol]weightedTable, len(servicePorts))
+	for p, ports := range servicePorts {
+		portTables[p] = zipfTable(len(ports), s)
+	}
+
+	meanPackets := opts.MeanPackets
+	if meanPackets == 0 {
+		meanPackets = 20
+	}
+	minSize, maxSize := opts.MinPacketSize, opts.MaxPacketSize
+	if minSize == 0 {
+		minSize = 40
+	}
+	if maxSize == 0 {
+		maxSize = max(1500, minSize)
+	}
+	minSize, maxSize = min(minSize, maxSize), max(minSize, maxSize)
+	meanDuration := opts.MeanDuration
+	if meanDuration == 0 {
+		meanDuration = 5 * time.Second
+	}
+	start := opts.Start
+	if start.IsZero() {
+		start = time.Now()
+	}
+	start = start.Truncate(time.Millisecond)
+	window := opts.Window
+	if window == 0 {
+		window = time.Minute
+	}
+	windowMillis := max(uint64(window/time.Millisecond), 1)
+
+	flows := make([]Flow, n)
+	for i := range flows {
+		f := &flows[i]
+		src := hostTable.pick(rng)
+		dst := hostTable.pick(rng)
+		for dst == src {
+			dst = hostTable.pick(rng)
+		}
+		f.SrcAddr, f.DstAddr = hosts[src], hosts[dst]
+
+		f.Protocol = protos[protoTable.pick(rng)]
+		switch f.Protocol {
+		case ProtoTCP, ProtoUDP:
+			f.SrcPort, _ = pickPort(&PortOptions{Range: DynamicPorts}, rng)
+			t := portTables[f.Protocol]
+			f.DstPort = servicePorts[f.Protocol][t.pick(rng)]
+		case ProtoICMP, ProtoICMPv6:
+			// Echo request, type 8 or 128 with code 0.
+			f.Protocol, f.DstPort = ProtoICMP, 8<<8
+			if f.SrcAddr.Is6() {
+				f.Protocol, f.DstPort = ProtoICMPv6, 128<<8
+			}
+		}
+
+		f.Packets = 1 + uint64(expRand(max(meanPackets-1, 0), rng))
+		f.Bytes = f.Packets * uint64(minSize+int(uniformUint64n(uint64(maxSize-minSize)+1, rng)))
+		f.Start = start.Add(time.Duration(uniformUint64n(windowMillis, rng)) * time.Millisecond)
+		f.End = f.Start
+		if f.Packets > 1 {
+			d := time.Duration(expRand(float64(meanDuration), rng))
+			f.End = f.Start.Add(d.Truncate(time.Millisecond))
+		}
+	}
+	slices.SortStableFunc(flows, func(a, b Flow) int { return a.Start.Compare(b.Start) })
+	return flows, nil
+}
@@ -0,0 +1,265 @@
+package randomizer_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"maps"
+	"math"
+	"net/netip"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/colduction/randomizer"
+)
+
+var flowStart = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func TestNetworkFlows(t *testing.T) {
+	const n = 20000
+	flows, err := randomizer.Network.Flows(n, &randomizer.FlowOptions{Hosts: 100, Start: flowStart})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(flows) != n {
+		t.Fatalf("got %d flows, want %d", len(flows), n)
+	}
+	hosts := map[netip.Addr]int{}
+	protos := map[randomizer.IPProtocol]int{}
+	for i, f := range flows {
+		if i > 0 && f.Start.Before(flows[i-1].Start) {
+			t.Fatal("flows are not sorted by start time")
+		}
+		if f.Start.Before(flowStart) || !f.Start.Before(flowStart.Add(time.Minute)) || f.End.Before(f.Start) {
+			t.Fatalf("flow %d has times %v - %v", i, f.Start, f.End)
+		}
+		if f.SrcAddr == f.DstAddr || !f.SrcAddr.Is4() {
+			t.Fatalf("flow %d has addresses %v -> %v", i, f.SrcAddr, f.DstAddr)
+		}
+		if f.Packets == 0 || f.Bytes < 40*f.Packets || f.Bytes > 1500*f.Packets {
+			t.Fatalf("flow %d has %d packets and %d bytes", i, f.Packets, f.Bytes)
+		}
+		switch f.Protocol {
+		case randomizer.ProtoTCP, randomizer.ProtoUDP:
+			if f.SrcPort < 49152 || f.DstPort == 0 {
+				t.Fatalf("flow %d has ports %d -> %d", i, f.SrcPort, f.DstPort)
+			}
+		case randomizer.ProtoICMP:
+			if f.SrcPort != 0 || f.DstPort != 8<<8 {
+				t.Fatalf("ICMP flow %d has ports %d -> %d", i, f.SrcPort, f.DstPort)
+			}
+		default:
+			t.Fatalf("flow %d has protocol %d", i, f.Protocol)
+		}
+		hosts[f.SrcAddr]++
+		protos[f.Protocol]++
+	}
+	if len(hosts) > 100 {
+		t.Fatalf("%d distinct source hosts, want at most 100", len(hosts))
+	}
+	// With s = 1 and 100 hosts the hottest host sources about 19% of flows.
+	counts := slices.Sorted(maps.Values(hosts))
+	if top := counts[len(counts)-1]; top < n/10 {
+		t.Fatalf("hottest host has %d of %d flows", top, n)
+	}
+	if tcp := protos[randomizer.ProtoTCP]; tcp < n*75/100 || tcp > n*85/100 {
+		t.Fatalf("TCP share %d of %d", tcp, n)
+	}
+}
+
+func TestNetworkFlowsOptions(t *testing.T) {
+	opts := &randomizer.FlowOptions{
+		HostPrefix:  netip.MustParsePrefix("2001:db8::/120"),
+		Hosts:       1000,
+		Protocols:   map[randomizer.IPProtocol]float64{randomizer.ProtoICMP: 1},
+		MeanPackets: 1,
+	}
+	flows, err := randomizer.Network.Flows(500, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hosts := map[netip.Addr]bool{}
+	for _, f := range flows {
+		if f.Protocol != randomizer.ProtoICMPv6 || f.DstPort != 128<<8 || f.Packets != 1 || !f.End.Equal(f.Start) {
+			t.Fatalf("unexpected flow %+v", f)
+		}
+		if !opts.HostPrefix.Contains(f.SrcAddr) || !opts.HostPrefix.Contains(f.DstAddr) {
+			t.Fatalf("flow %v -> %v outside %v", f.SrcAddr, f.DstAddr, opts.HostPrefix)
+		}
+		hosts[f.SrcAddr] = true
+	}
+	if len(hosts) > 256 {
+		t.Fatalf("%d hosts in a /120", len(hosts))
+	}
+
+	for _, bad := range []*randomizer.FlowOptions{
+		{Hosts: -1},
+		{Hosts: 1},
+		{ZipfExponent: math.NaN()},
+		{MeanPackets: math.NaN()},
+		{MeanPackets: math.Inf(1)},
+		{Protocols: map[randomizer.IPProtocol]float64{}},
+		{Protocols: map[randomizer.IPProtocol]float64{randomizer.ProtoTCP: -1}},
+	} {
+		if _, err := randomizer.Network.Flows(1, bad); !errors.Is(err, randomizer.ErrInvalidFlowOptions) {
+			t.Errorf("Flows(%+v) error = %v", bad, err)
+		}
+	}
+	single := &randomizer.FlowOptions{HostPrefix: netip.MustParsePrefix("192.0.2.1/32")}
+	if _, err := randomizer.Network.Flows(1, single); !errors.Is(err, randomizer.ErrPrefixExhausted) {
+		t.Errorf("Flows with a single host error = %v", err)
+	}
+}
+
+func testFlows(t *testing.T) []randomizer.Flow {
+	t.Helper()
+	v4, err := randomizer.Network.Flows(150, &randomizer.FlowOptions{Start: flowStart})
+	if err != nil {
+		t.Fatal(err)
+	}
+	v6, err := randomizer.Network.Flows(50, &randomizer.FlowOptions{Start: flowStart, HostPrefix: netip.MustParsePrefix("2001:db8::/64")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	flows := append(v4, v6...)
+	slices.SortStableFunc(flows, func(a, b randomizer.Flow) int { return a.Start.Compare(b.Start) })
+	return flows
+}
+
+func TestWriteFlowsCSV(t *testing.T) {
+	flows := testFlows(t)
+	var buf bytes.Buffer
+	if err := randomizer.WriteFlowsCSV(&buf, flows); err != nil {
+		t.Fatal(err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != len(flows)+1 || records[0][0] != "src_addr" {
+		t.Fatalf("got %d records with header %v", len(records), records[0])
+	}
+	for i, r := range records[1:] {
+		f := flows[i]
+		end, _ := time.Parse(time.RFC3339Nano, r[8])
+		if r[0] != f.SrcAddr.String() || r[3] != strconv.Itoa(int(f.DstPort)) ||
+			r[6] != strconv.FormatUint(f.Bytes, 10) || !end.Equal(f.End) {
+			t.Fatalf("record %d = %v, flow %+v", i, r, f)
+		}
+	}
+}
+
+func TestWriteFlowsJSON(t *testing.T) {
+	flows := testFlows(t)
+	var buf bytes.Buffer
+	if err := randomizer.WriteFlowsJSON(&buf, flows); err != nil {
+		t.Fatal(err)
+	}
+	sc := bufio.NewScanner(&buf)
+	i := 0
+	for ; sc.Scan(); i++ {
+		var f randomizer.Flow
+		if err := json.Unmarshal(sc.Bytes(), &f); err != nil {
+			t.Fatal(err)
+		}
+		if f != flows[i] {
+			t.Fatalf("line %d decodes to %+v, want %+v", i, f, flows[i])
+		}
+	}
+	if i != len(flows) {
+		t.Fatalf("got %d lines, want %d", i, len(flows))
+	}
+}
+
+func TestWriteFlowsIPFIX(t *testing.T) {
+	flows := testFlows(t)
+	var buf bytes.Buffer
+	if err := randomizer.WriteFlowsIPFIX(&buf, flows, 42); err != nil {
+		t.Fatal(err)
+	}
+	be := binary.BigEndian
+	b := buf.Bytes()
+	var got []randomizer.Flow
+	for len(b) > 0 {
+		n := int(be.Uint16(b[2:]))
+		if be.Uint16(b) != 10 || n > 1400 || n > len(b) || be.Uint32(b[12:]) != 42 {
+			t.Fatalf("bad message header %x", b[:16])
+		}
+		if seq := be.Uint32(b[8:]); int(seq) != len(got) {
+			t.Fatalf("sequence number %d, want %d", seq, len(got))
+		}
+		msg := b[16:n]
+		for len(msg) > 0 {
+			id, setLen := be.Uint16(msg), int(be.Uint16(msg[2:]))
+			set := msg[4:setLen]
+			switch id {
+			case 2:
+				if be.Uint16(set) != 256 || be.Uint16(set[2:]) != 9 || be.Uint16(set[40:]) != 257 {
+					t.Fatalf("bad template set %x", set)
+				}
+			case 256, 257:
+				addrLen := 4
+				if id == 257 {
+					addrLen = 16
+				}
+				for len(set) > 0 {
+					src, _ := netip.AddrFromSlice(set[:addrLen])
+					dst, _ := netip.AddrFromSlice(set[addrLen : 2*addrLen])
+					r := set[2*addrLen:]
+					got = append(got, randomizer.Flow{
+						SrcAddr:  src,
+						DstAddr:  dst,
+						SrcPort:  be.Uint16(r),
+						DstPort:  be.Uint16(r[2:]),
+						Protocol: randomizer.IPProtocol(r[4]),
+						Packets:  be.Uint64(r[5:]),
+						Bytes:    be.Uint64(r[13:]),
+						Start:    time.UnixMilli(int64(be.Uint64(r[21:]))).UTC(),
+						End:      time.UnixMilli(int64(be.Uint64(r[29:]))).UTC(),
+					})
+					set = r[37:]
+				}
+			default:
+				t.Fatalf("unexpected set ID %d", id)
+			}
+			msg = msg[setLen:]
+		}
+		b = b[n:]
+	}
+	if len(got) != len(flows) {
+		t.Fatalf("decoded %d records, want %d", len(got), len(flows))
+	}
+	for i := range flows {
+		if got[i] != flows[i] {
+			t.Fatalf("record %d = %+v, want %+v", i, got[i], flows[i])
+		}
+	}
+}
+
+func TestWriteFlowsIPFIXInvalid(t *testing.T) {
+	v4, v6 := netip.MustParseAddr("192.0.2.1"), netip.MustParseAddr("2001:db8::1")
+	cases := []struct {
+		flow randomizer.Flow
+		err  error
+	}{
+		{randomizer.Flow{SrcAddr: v4, DstAddr: v6}, randomizer.ErrAddrFamilyMismatch},
+		{randomizer.Flow{SrcAddr: v6, DstAddr: netip.AddrFrom16(v4.As16())}, nil},
+		{randomizer.Flow{SrcAddr: v4, DstAddr: netip.AddrFrom16(v4.As16())}, randomizer.ErrAddrFamilyMismatch},
+		{randomizer.Flow{SrcAddr: v4}, randomizer.ErrInvalidFlowAddr},
+	}
+	for _, c := range cases {
+		var buf bytes.Buffer
+		flows := []randomizer.Flow{{SrcAddr: v4, DstAddr: v4}, c.flow}
+		if err := randomizer.WriteFlowsIPFIX(&buf, flows, 0); !errors.Is(err, c.err) {
+			t.Errorf("WriteFlowsIPFIX(%v -> %v) error = %v, want %v", c.flow.SrcAddr, c.flow.DstAddr, err, c.err)
+		}
+		if c.err != nil && buf.Len() != 0 {
+			t.Errorf("WriteFlowsIPFIX(%v -> %v) wrote %d bytes before failing", c.flow.SrcAddr, c.flow.DstAddr, buf.Len())
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package randomizer
+
+import (
+	"encoding/binary"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"time"
+)
+
+// ErrInvalidFlowAddr is returned by WriteFlowsIPFIX for a flow with a zero
+// source or destination address.
+var ErrInvalidFlowAddr = errors.New("randomizer: flow address is not valid")
+
+// flowCSVHeader names the columns written by WriteFlowsCSV; they match the
+// JSON field names of Flow.
+var flowCSVHeader = []string{"src_addr", "dst_addr", "src_port", "dst_port", "protocol", "packets", "bytes", "start", "end"}
+
+// WriteFlowsCSV writes flows to w as CSV with a header row. Addresses use
+// their textual form and times RFC 3339 with nanoseconds.
+func WriteFlowsCSV(w io.Writer, flows []Flow) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(flowCSVHeader); err != nil {
+		return err
+	}
+	record := make([]string, len(flowCSVHeader))
+	for i := range flows {
+		f := &flows[i]
+		record[0] = f.SrcAddr.String()
+		record[1] = f.DstAddr.String()
+		record[2] = strconv.FormatUint(uint64(f.SrcPort), 10)
+		record[3] = strconv.FormatUint(uint64(f.DstPort), 10)
+		record[4] = strconv.FormatUint(uint64(f.Protocol), 10)
+		record[5] = strconv.FormatUint(f.Packets, 10)
+		record[6] = strconv.FormatUint(f.Bytes, 10)
+		record[7] = f.Start.Format(time.RFC3339Nano)
+		record[8] = f.End.Format(time.RFC3339Nano)
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteFlowsJSON writes flows to w as JSON lines, one object per flow.
+func WriteFlowsJSON(w io.Writer, flows []Flow) error {
+	enc := json.NewEncoder(w)
+	for i := range flows {
+		if err := enc.Encode(&flows[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// IPFIX message layout.
+// ref: https://datatracker.ietf.org/doc/html/rfc7011#section-3
+const (
+	ipfixVersion        = 10
+	ipfixHeaderLen      = 16
+	ipfixSetHeaderLen   = 4
+	ipfixTemplateSetID  = 2
+	ipfixTemplateIPv4   = 256
+	ipfixTemplateIPv6   = 257
+	ipfixMaxMessageLen  = 1400 // fits a typical path MTU when sent over UDP
+	ipfixRecordLenFixed = 2 + 2 + 1 + 8 + 8 + 8 + 8
+	ipfixRecordLenIPv4  = ipfixRecordLenFixed + 2*4
+	ipfixRecordLenIPv6  = ipfixRecordLenFixed + 2*16
+	ipfixTemplateFields = 9
+	ipfixTemplateSetLen = ipfixSetHeaderLen + 2*(4+4*ipfixTemplateFields)
+)
+
+// IPFIX information element identifiers.
+// ref: https://www.iana.org/assignments/ipfix
+const (
+	ieOctetDeltaCount          = 1
+	iePacketDeltaCount         = 2
+	ieProtocolIdentifier       = 4
+	ieSourceTransportPort      = 7
+	ieSourceIPv4Address        = 8
+	ieDestinationTransportPort = 11
+	ieDestinationIPv4Address   = 12
+	ieSourceIPv6Address        = 27
+	ieDestinationIPv6Address   = 28
+	ieFlowStartMilliseconds    = 152
+	ieFlowEndMilliseconds      = 153
+)
+
+// appendIPFIXTemplate appends a template record describing the data records
+// written by appendIPFIXRecord.
+func appendIPFIXTemplate(out []byte, id uint16, is6 bool) []byte {
+	src, dst, addrLen := uint16(ieSourceIPv4Address), uint16(ieDestinationIPv4Address), uint16(4)
+	if is6 {
+		src, dst, addrLen = ieSourceIPv6Address, ieDestinationIPv6Address, 16
+	}
+	be := binary.BigEndian
+	out = be.AppendUint16(out, id)
+	out = be.AppendUint16(out, ipfixTemplateFields)
+	for _, f := range [ipfixTemplateFields][2]uint16{
+		{src, addrLen},
+		{dst, addrLen},
+		{ieSourceTransportPort, 2},
+		{ieDestinationTransportPort, 2},
+		{ieProtocolIdentifier, 1},
+		{iePacketDeltaCount, 8},
+		{ieOctetDeltaCount, 8},
+		{ieFlowStartMilliseconds, 8},
+		{ieFlowEndMilliseconds, 8},
+	} {
+		out = be.AppendUint16(out, f[0])
+		out = be.AppendUint16(out, f[1])
+	}
+	return out
+}
+
+func appendIPFIXRecord(out []byte, f *Flow) []byte {
+	be := binary.BigEndian
+	out = append(out, f.SrcAddr.AsSlice()...)
+	out = append(out, f.DstAddr.AsSlice()...)
+	out = be.AppendUint16(out, f.SrcPort)
+	out = be.AppendUint16(out, f.DstPort)
+	out = append(out, byte(f.Protocol))
+	out = be.AppendUint64(out, f.Packets)
+	out = be.AppendUint64(out, f.Bytes)
+	out = be.AppendUint64(out, uint64(f.Start.UnixMilli()))
+	return be.AppendUint64(out, uint64(f.End.UnixMilli()))
+}
+
+// WriteFlowsIPFIX writes flows to w as a stream of IPFIX messages for the
+// given observation domain. Every message starts with the IPv4 and IPv6
+// templates so that each one can be decoded on its own, and is at most 1400
+// bytes long. Export times are the latest flow end time in each message.
+// Flows are checked before anything is written: it returns ErrInvalidFlowAddr
+// if a flow has an invalid address and ErrAddrFamilyMismatch if its source
+// and destination are not both IPv4 or both IPv6.
+func WriteFlowsIPFIX(w io.Writer, flows []Flow, domainID uint32) error {
+	for i := range flows {
+		f := &flows[i]
+		if !f.SrcAddr.IsValid() || !f.DstAddr.IsValid() {
+			return ErrInvalidFlowAddr
+		}
+		if f.SrcAddr.Is4() != f.DstAddr.Is4() {
+			return ErrAddrFamilyMismatch
+		}
+	}
+	be := binary.BigEndian
+	buf := make([]byte, 0, ipfixMaxMessageLen)
+	var seq uint32
+	for len(flows) > 0 {
+		buf = buf[:ipfixHeaderLen]
+		buf = be.AppendUint16(buf, ipfixTemplateSetID)
+		buf = be.AppendUint16(buf, ipfixTemplateSetLen)
+		buf = appendIPFIXTemplate(buf, ipfixTemplateIPv4, false)
+		buf = appendIPFIXTemplate(buf, ipfixTemplateIPv6, true)
+
+		// Fill the message with runs of same-family records, each run in its
+		// own data set.
+		var records int
+		var export time.Time
+		set := -1
+		for len(flows) > 0 {
+			f := &flows[0]
+			is6 := f.SrcAddr.Is6()
+			recordLen, setID := ipfixRecordLenIPv4, uint16(ipfixTemplateIPv4)
+			if is6 {
+				recordLen, setID = ipfixRecordLenIPv6, ipfixTemplateIPv6
+			}
+			if set < 0 || be.Uint16(buf[set:]) != setID {
+				if len(buf)+ipfixSetHeaderLen+recordLen > ipfixMaxMessageLen {
+					break
+				}
+				if set >= 0 {
+					be.PutUint16(buf[set+2:], uint16(len(buf)-set))
+				}
+				set = len(buf)
+				buf = be.AppendUint16(buf, setID)
+				buf = be.AppendUint16(buf, 0)
+			} else if len(buf)+recordLen > ipfixMaxMessageLen {
+				break
+			}
+			buf = appendIPFIXRecord(buf, f)
+			if f.End.After(export) {
+				export = f.End
+			}
+			records++
+			flows = flows[1:]
+		}
+		be.PutUint16(buf[set+2:], uint16(len(buf)-set))
+
+		be.PutUint16(buf[0:], ipfixVersion)
+		be.PutUint16(buf[2:], uint16(len(buf)))
+		be.PutUint32(buf[4:], uint32(export.Unix()))
+		be.PutUint32(buf[8:], seq)
+		be.PutUint32(buf[12:], domainID)
+		if _, err := w.Write(buf); err != nil {
+			return err
+		}
+		seq += uint32(records)
+	}
+	return nil
+}
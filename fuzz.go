@@ -0,0 +1,204 @@
+package randomizer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"unicode/utf8"
+)
+
+// ErrUnsupportedFuzzType is returned when a value cannot be a fuzz argument.
+var ErrUnsupportedFuzzType = errors.New("randomizer: unsupported fuzz argument type")
+
+// FuzzType is the set of argument types supported by go test -fuzz.
+type FuzzType interface {
+	[]byte | string | bool | float32 | float64 |
+		int | int8 | int16 | int32 | int64 |
+		uint | uint8 | uint16 | uint32 | uint64
+}
+
+// FuzzArg generates one argument of a fuzz target.
+type FuzzArg struct {
+	gen func(r *Rand) any
+}
+
+// FuzzArgOf returns a FuzzArg producing values from gen, for example
+//
+//	randomizer.FuzzArgOf(func() string { return randomizer.Network.URL(nil) })
+//
+// gen draws from wherever it likes, so its values do not replay with a
+// seeded Rand; use FuzzArgFrom for that.
+func FuzzArgOf[T FuzzType](gen func() T) FuzzArg {
+	return FuzzArg{gen: func(*Rand) any { return gen() }}
+}
+
+// FuzzArgFrom returns a FuzzArg producing values from gen given the Rand the
+// arguments are generated with, for example
+//
+//	randomizer.FuzzArgFrom(func(r *randomizer.Rand) string { return r.Network().URL(nil) })
+//
+// r is nil when drawing from DefaultHashPool, which its generators handle.
+func FuzzArgFrom[T FuzzType](gen func(r *Rand) T) FuzzArg {
+	return FuzzArg{gen: func(r *Rand) any { return gen(r) }}
+}
+
+// FuzzArgFor returns a FuzzArg producing values of type T from the default
+// generators: full-range integers, floats in [0, 1), alphanumeric strings and
+// random bytes of up to 32 bytes.
+func FuzzArgFor[T FuzzType]() FuzzArg {
+	const maxLen = 32
+	var zero T
+	var gen func(*wordRNG) any
+	switch any(zero).(type) {
+	case []byte:
+		gen = func(rng *wordRNG) any {
+			b := make([]byte, uniformUint64n(maxLen+1, rng))
+			fillRandomBytes(b, rng)
+			return b
+		}
+	case string:
+		gen = func(rng *wordRNG) any {
+			b := make([]byte, uniformUint64n(maxLen+1, rng))
+			fillAlphabet(b, alphanumdict, rng)
+			return string(b)
+		}
+	case bool:
+		gen = func(rng *wordRNG) any { return rng.next64()&1 == 1 }
+	case float32:
+		gen = func(rng *wordRNG) any {
+			const inv24 = float32(1.0 / (1 << 24))
+			return float32(rng.next64()>>40) * inv24
+		}
+	case float64:
+		gen = func(rng *wordRNG) any { return rng.float64() }
+	case int:
+		gen = func(rng *wordRNG) any { return int(rng.next64()) }
+	case int8:
+		gen = func(rng *wordRNG) any { return int8(rng.next64()) }
+	case int16:
+		gen = func(rng *wordRNG) any { return int16(rng.next64()) }
+	case int32:
+		gen = func(rng *wordRNG) any { return int32(rng.next64()) }
+	case int64:
+		gen = func(rng *wordRNG) any { return int64(rng.next64()) }
+	case uint:
+		gen = func(rng *wordRNG) any { return uint(rng.next64()) }
+	case uint8:
+		gen = func(rng *wordRNG) any { return uint8(rng.next64()) }
+	case uint16:
+		gen = func(rng *wordRNG) any { return uint16(rng.next64()) }
+	case uint32:
+		gen = func(rng *wordRNG) any { return uint32(rng.next64()) }
+	case uint64:
+		gen = func(rng *wordRNG) any { return rng.next64() }
+	}
+	return FuzzArg{gen: func(r *Rand) any { return gen(r.source()) }}
+}
+
+// GenerateFuzzArgs returns one value from each of args, in order.
+func GenerateFuzzArgs(args ...FuzzArg) []any {
+	return generateFuzzArgs(nil, args)
+}
+
+// FuzzArgs is like GenerateFuzzArgs but draws from r, so the same seed
+// yields the same values for arguments from FuzzArgFor and FuzzArgFrom.
+func (r *Rand) FuzzArgs(args ...FuzzArg) []any {
+	return generateFuzzArgs(r, args)
+}
+
+func generateFuzzArgs(r *Rand, args []FuzzArg) []any {
+	values := make([]any, len(args))
+	for i, a := range args {
+		values[i] = a.gen(r)
+	}
+	return values
+}
+
+// MarshalFuzzCorpus encodes values as a corpus file in the "go test fuzz v1"
+// format read by go test.
+func MarshalFuzzCorpus(values ...any) ([]byte, error) {
+	// Each line matches what go test itself writes with fmt: %q for strings,
+	// bytes and runes, and %v for numbers and bools.
+	out := []byte("go test fuzz v1\n")
+	for _, v := range values {
+		switch v := v.(type) {
+		case []byte:
+			out = strconv.AppendQuote(append(out, "[]byte("...), string(v))
+		case string:
+			out = strconv.AppendQuote(append(out, "string("...), v)
+		case bool:
+			out = strconv.AppendBool(append(out, "bool("...), v)
+		case float32:
+			if math.IsNaN(float64(v)) && math.Float32bits(v) != math.Float32bits(float32(math.NaN())) {
+				out = strconv.AppendUint(append(out, "math.Float32frombits(0x"...), uint64(math.Float32bits(v)), 16)
+			} else {
+				out = strconv.AppendFloat(append(out, "float32("...), float64(v), 'g', -1, 32)
+			}
+		case float64:
+			if math.IsNaN(v) && math.Float64bits(v) != math.Float64bits(math.NaN()) {
+				out = strconv.AppendUint(append(out, "math.Float64frombits(0x"...), math.Float64bits(v), 16)
+			} else {
+				out = strconv.AppendFloat(append(out, "float64("...), v, 'g', -1, 64)
+			}
+		case int:
+			out = strconv.AppendInt(append(out, "int("...), int64(v), 10)
+		case int8:
+			out = strconv.AppendInt(append(out, "int8("...), int64(v), 10)
+		case int16:
+			out = strconv.AppendInt(append(out, "int16("...), int64(v), 10)
+		case int32:
+			// int32 is rune; valid code points are written as rune literals.
+			if utf8.ValidRune(v) {
+				out = strconv.AppendQuoteRune(append(out, "rune("...), v)
+			} else {
+				out = strconv.AppendInt(append(out, "int32("...), int64(v), 10)
+			}
+		case int64:
+			out = strconv.AppendInt(append(out, "int64("...), v, 10)
+		case uint:
+			out = strconv.AppendUint(append(out, "uint("...), uint64(v), 10)
+		case uint8:
+			// uint8 is byte and is written as a byte literal.
+			out = strconv.AppendQuoteRune(append(out, "byte("...), rune(v))
+		case uint16:
+			out = strconv.AppendUint(append(out, "uint16("...), uint64(v), 10)
+		case uint32:
+			out = strconv.AppendUint(append(out, "uint32("...), uint64(v), 10)
+		case uint64:
+			out = strconv.AppendUint(append(out, "uint64("...), v, 10)
+		default:
+			return nil, ErrUnsupportedFuzzType
+		}
+		out = append(out, ")\n"...)
+	}
+	return out, nil
+}
+
+// WriteFuzzCorpus writes n generated inputs for the fuzz target named name to
+// the seed corpus directory testdata/fuzz/<name> under dir, creating it if
+// needed. Files are named after a hash of their contents as go test does, so
+// rewriting an identical input is harmless. It returns the paths written.
+func WriteFuzzCorpus(dir, name string, n int, args ...FuzzArg) ([]string, error) {
+	corpus := filepath.Join(dir, "testdata", "fuzz", name)
+	if err := os.MkdirAll(corpus, 0o755); err != nil {
+		return nil, err
+	}
+	paths := make([]string, 0, n)
+	for range n {
+		data, err := MarshalFuzzCorpus(GenerateFuzzArgs(args...)...)
+		if err != nil {
+			return paths, err
+		}
+		sum := sha256.Sum256(data)
+		path := filepath.Join(corpus, hex.EncodeToString(sum[:])[:16])
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			return paths, err
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
@@ -0,0 +1,157 @@
+package randomizer_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/colduction/randomizer"
+)
+
+func TestMarshalFuzzCorpus(t *testing.T) {
+	got, err := randomizer.MarshalFuzzCorpus(
+		[]byte("a\x00"), "hi\n", true, float32(1.5), math.Inf(-1), math.NaN(),
+		math.Float64frombits(0x7ff8000000000002), -3, int8(-8), int16(16), rune('é'), int32(-1),
+		int64(64), uint(1), byte('x'), byte(0xff), uint16(16), uint32(32), uint64(1<<63),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `go test fuzz v1
+[]byte("a\x00")
+string("hi\n")
+bool(true)
+float32(1.5)
+float64(-Inf)
+float64(NaN)
+math.Float64frombits(0x7ff8000000000002)
+int(-3)
+int8(-8)
+int16(16)
+rune('é')
+int32(-1)
+int64(64)
+uint(1)
+byte('x')
+byte('ÿ')
+uint16(16)
+uint32(32)
+uint64(9223372036854775808)
+`
+	if string(got) != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", got, want)
+	}
+	if _, err := randomizer.MarshalFuzzCorpus(struct{}{}); !errors.Is(err, randomizer.ErrUnsupportedFuzzType) {
+		t.Fatalf("err = %v, want ErrUnsupportedFuzzType", err)
+	}
+}
+
+func TestMarshalFuzzCorpusMatchesFmt(t *testing.T) {
+	for _, v := range []any{1e21, 1e-7, float32(0.1), -0.0, math.Inf(1), byte('\n'), byte(0x80)} {
+		got, err := randomizer.MarshalFuzzCorpus(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		line := strings.TrimPrefix(string(got), "go test fuzz v1\n")
+		var want string
+		if b, ok := v.(byte); ok {
+			want = fmt.Sprintf("byte(%q)\n", b)
+		} else {
+			want = fmt.Sprintf("%T(%v)\n", v, v)
+		}
+		if line != want {
+			t.Errorf("%v: got %q, want %q", v, line, want)
+		}
+	}
+}
+
+func TestWriteFuzzCorpus(t *testing.T) {
+	dir := t.TempDir()
+	paths, err := randomizer.WriteFuzzCorpus(dir, "FuzzParse", 20,
+		randomizer.FuzzArgOf(func() string { return randomizer.Network.URL(nil) }),
+		randomizer.FuzzArgFor[[]byte](),
+		randomizer.FuzzArgFor[uint16](),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) != 20 {
+		t.Fatalf("got %d paths, want 20", len(paths))
+	}
+	for _, p := range paths {
+		if filepath.Dir(p) != filepath.Join(dir, "testdata", "fuzz", "FuzzParse") || len(filepath.Base(p)) != 16 {
+			t.Fatalf("unexpected path %s", p)
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+		if len(lines) != 4 || lines[0] != "go test fuzz v1" ||
+			!strings.HasPrefix(lines[1], `string("`) || !strings.HasPrefix(lines[2], `[]byte("`) || !strings.HasPrefix(lines[3], "uint16(") {
+			t.Fatalf("unexpected corpus file %s:\n%s", p, data)
+		}
+	}
+}
+
+func TestFuzzArgForTypes(t *testing.T) {
+	args := []randomizer.FuzzArg{
+		randomizer.FuzzArgFor[[]byte](), randomizer.FuzzArgFor[string](), randomizer.FuzzArgFor[bool](),
+		randomizer.FuzzArgFor[float32](), randomizer.FuzzArgFor[float64](),
+		randomizer.FuzzArgFor[int](), randomizer.FuzzArgFor[int8](), randomizer.FuzzArgFor[int16](),
+		randomizer.FuzzArgFor[int32](), randomizer.FuzzArgFor[int64](),
+		randomizer.FuzzArgFor[uint](), randomizer.FuzzArgFor[uint8](), randomizer.FuzzArgFor[uint16](),
+		randomizer.FuzzArgFor[uint32](), randomizer.FuzzArgFor[uint64](),
+	}
+	paths, err := randomizer.WriteFuzzCorpus(t.TempDir(), "FuzzAll", 5, args...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(paths[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := bytes.Count(data, []byte("\n")); n != len(args)+1 {
+		t.Fatalf("corpus file has %d lines, want %d:\n%s", n, len(args)+1, data)
+	}
+}
+
+func TestGenerateFuzzArgs(t *testing.T) {
+	got := randomizer.GenerateFuzzArgs(
+		randomizer.FuzzArgOf(func() string { return "x" }),
+		randomizer.FuzzArgFor[uint16](),
+		randomizer.FuzzArgFor[[]byte](),
+	)
+	if len(got) != 3 || got[0] != "x" {
+		t.Fatalf("GenerateFuzzArgs = %#v", got)
+	}
+	if _, ok := got[1].(uint16); !ok {
+		t.Fatalf("second value is %T, want uint16", got[1])
+	}
+	if b, ok := got[2].([]byte); !ok || len(b) > 32 {
+		t.Fatalf("third value = %#v, want at most 32 bytes", got[2])
+	}
+}
+
+func TestRandFuzzArgs(t *testing.T) {
+	args := []randomizer.FuzzArg{
+		randomizer.FuzzArgFor[[]byte](), randomizer.FuzzArgFor[string](), randomizer.FuzzArgFor[bool](),
+		randomizer.FuzzArgFor[float32](), randomizer.FuzzArgFor[int16](), randomizer.FuzzArgFor[uint64](),
+		randomizer.FuzzArgFrom(func(r *randomizer.Rand) string { return r.Network().URL(nil) }),
+	}
+	a, b := randomizer.NewRand(7), randomizer.NewRand(7)
+	for range 10 {
+		x, y := a.FuzzArgs(args...), b.FuzzArgs(args...)
+		if fmt.Sprintf("%#v", x) != fmt.Sprintf("%#v", y) {
+			t.Fatalf("same seed generated %#v and %#v", x, y)
+		}
+	}
+	if s, ok := randomizer.GenerateFuzzArgs(args...)[6].(string); !ok || !strings.Contains(s, "://") {
+		t.Fatalf("FuzzArgFrom with DefaultHashPool = %#v, want a URL", s)
+	}
+}
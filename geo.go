@@ -0,0 +1,219 @@
+package randomizer
+
+import (
+	"errors"
+	"math"
+	"strconv"
+)
+
+var (
+	// ErrInvalidCoordinate is returned for a latitude outside [-90, 90] or a
+	// longitude outside [-180, 180].
+	ErrInvalidCoordinate = errors.New("randomizer: invalid coordinate")
+	// ErrInvalidBoundingBox is returned for a bounding box with invalid
+	// corners or MinLat above MaxLat.
+	ErrInvalidBoundingBox = errors.New("randomizer: invalid bounding box")
+	// ErrInvalidRadius is returned for a negative or NaN radius.
+	ErrInvalidRadius = errors.New("randomizer: invalid radius")
+	// ErrInvalidPolygon is returned for a polygon with no area or with a ring
+	// that is not closed, has fewer than four positions or holds an invalid
+	// coordinate.
+	ErrInvalidPolygon = errors.New("randomizer: invalid polygon")
+	// ErrPolygonTooSmall is returned by Geo.PointInPolygon when a valid
+	// polygon covers too little of its bounding box for sampling to find a
+	// point inside it.
+	ErrPolygonTooSmall = errors.New("randomizer: polygon too small to sample")
+)
+
+// EarthRadius is the mean radius of the Earth in meters, used for distances.
+// ref: https://en.wikipedia.org/wiki/Earth_radius#Arithmetic_mean_radius
+const EarthRadius = 6371008.8
+
+// maxPolygonTries bounds the candidates PointInPolygon draws from the bounding
+// box of a polygon.
+const maxPolygonTries = 1 << 20
+
+type geo struct {
+	r *Rand // nil draws from DefaultHashPool
+}
+
+// Geo generates geographic coordinates.
+var Geo geo
+
+// LatLng is a WGS 84 coordinate in degrees.
+type LatLng struct {
+	Lat, Lng float64
+}
+
+// String formats p as "lat,lng".
+func (p LatLng) String() string {
+	b := strconv.AppendFloat(nil, p.Lat, 'f', -1, 64)
+	b = append(b, ',')
+	return string(strconv.AppendFloat(b, p.Lng, 'f', -1, 64))
+}
+
+// Valid reports whether p has a latitude in [-90, 90] and a longitude in
+// [-180, 180].
+func (p LatLng) Valid() bool {
+	return p.Lat >= -90 && p.Lat <= 90 && p.Lng >= -180 && p.Lng <= 180
+}
+
+// DistanceTo returns the great-circle distance from p to q in meters on a
+// sphere of radius EarthRadius, using the haversine formula.
+func (p LatLng) DistanceTo(q LatLng) float64 {
+	lat1, lat2 := radians(p.Lat), radians(q.Lat)
+	dLat, dLng := lat2-lat1, radians(q.Lng-p.Lng)
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * EarthRadius * math.Asin(math.Sqrt(min(h, 1)))
+}
+
+// BoundingBox is an area between two latitudes and two longitudes. A MinLng
+// greater than MaxLng describes a box that crosses the antimeridian.
+type BoundingBox struct {
+	MinLat, MinLng, MaxLat, MaxLng float64
+}
+
+// Polygon is a GeoJSON-style polygon: the first ring is the exterior and any
+// further rings are holes. Each ring is closed, repeating its first position
+// last. As in GeoJSON, edges are straight lines in longitude and latitude.
+type Polygon [][]LatLng
+
+// Contains reports whether p lies inside poly and outside its holes, using
+// the even-odd rule.
+func (poly Polygon) Contains(p LatLng) bool {
+	in := false
+	for _, ring := range poly {
+		for i := 1; i < len(ring); i++ {
+			a, b := ring[i-1], ring[i]
+			if (a.Lat > p.Lat) != (b.Lat > p.Lat) &&
+				p.Lng < a.Lng+(p.Lat-a.Lat)*(b.Lng-a.Lng)/(b.Lat-a.Lat) {
+				in = !in
+			}
+		}
+	}
+	return in
+}
+
+// bounds validates poly and returns the bounding box of its exterior ring.
+func (poly Polygon) bounds() (BoundingBox, error) {
+	if len(poly) == 0 {
+		return BoundingBox{}, ErrInvalidPolygon
+	}
+	for _, ring := range poly {
+		if len(ring) < 4 || ring[0] != ring[len(ring)-1] {
+			return BoundingBox{}, ErrInvalidPolygon
+		}
+		for _, p := range ring {
+			if !p.Valid() {
+				return BoundingBox{}, ErrInvalidPolygon
+			}
+		}
+	}
+	b := BoundingBox{MinLat: 90, MinLng: 180, MaxLat: -90, MaxLng: -180}
+	var area float64
+	ext := poly[0]
+	for i, p := range ext {
+		b.MinLat, b.MaxLat = min(b.MinLat, p.Lat), max(b.MaxLat, p.Lat)
+		b.MinLng, b.MaxLng = min(b.MinLng, p.Lng), max(b.MaxLng, p.Lng)
+		if i > 0 {
+			area += ext[i-1].Lng*p.Lat - p.Lng*ext[i-1].Lat
+		}
+	}
+	if area == 0 {
+		return BoundingBox{}, ErrInvalidPolygon
+	}
+	return b, nil
+}
+
+// Point returns a point distributed uniformly over the surface of the
+// sphere. Drawing the latitude uniformly instead would crowd points near the
+// poles, where meridians converge.
+func (g geo) Point() LatLng {
+	rng := g.r.source()
+	return randomInBox(-1, 1, -180, 360, rng)
+}
+
+// PointInBox returns a point distributed uniformly by area within b.
+func (g geo) PointInBox(b BoundingBox) (LatLng, error) {
+	if !(LatLng{b.MinLat, b.MinLng}).Valid() || !(LatLng{b.MaxLat, b.MaxLng}).Valid() || b.MinLat > b.MaxLat {
+		return LatLng{}, ErrInvalidBoundingBox
+	}
+	rng := g.r.source()
+	return pointInBox(b, rng), nil
+}
+
+func pointInBox(b BoundingBox, rng *wordRNG) LatLng {
+	width := b.MaxLng - b.MinLng
+	if width < 0 {
+		width += 360
+	}
+	return randomInBox(math.Sin(radians(b.MinLat)), math.Sin(radians(b.MaxLat)), b.MinLng, width, rng)
+}
+
+// randomInBox returns a point whose latitude has its sine uniform in
+// [sinLo, sinHi), which makes it uniform by area, and whose longitude is
+// uniform in [lng, lng+width) wrapped to [-180, 180).
+func randomInBox(sinLo, sinHi, lng, width float64, rng *wordRNG) LatLng {
+	lat := degrees(math.Asin(sinLo + rng.float64()*(sinHi-sinLo)))
+	return LatLng{Lat: lat, Lng: wrapLng(lng + rng.float64()*width)}
+}
+
+// PointInRadius returns a point distributed uniformly by area within the
+// given great-circle distance in meters of center. Radii beyond half the
+// circumference of the Earth cover the whole sphere.
+func (g geo) PointInRadius(center LatLng, meters float64) (LatLng, error) {
+	if !center.Valid() {
+		return LatLng{}, ErrInvalidCoordinate
+	}
+	if !(meters >= 0) {
+		return LatLng{}, ErrInvalidRadius
+	}
+	rng := g.r.source()
+	// The area of a spherical cap grows with 1 - cos(dist), so drawing cos(dist)
+	// uniformly spreads the points evenly over the cap.
+	maxDist := min(meters/EarthRadius, math.Pi)
+	dist := math.Acos(1 - rng.float64()*(1-math.Cos(maxDist)))
+	bearing := 2 * math.Pi * rng.float64()
+
+	lat1, lng1 := radians(center.Lat), radians(center.Lng)
+	sinLat2 := math.Sin(lat1)*math.Cos(dist) + math.Cos(lat1)*math.Sin(dist)*math.Cos(bearing)
+	lat2 := math.Asin(max(-1, min(1, sinLat2)))
+	lng2 := lng1 + math.Atan2(math.Sin(bearing)*math.Sin(dist)*math.Cos(lat1), math.Cos(dist)-math.Sin(lat1)*sinLat2)
+	return LatLng{Lat: degrees(lat2), Lng: wrapLng(degrees(lng2))}, nil
+}
+
+// PointInPolygon returns a point distributed uniformly by area within poly,
+// drawn from its bounding box until one falls inside. Polygons crossing the
+// antimeridian must be split, as GeoJSON requires. It returns
+// ErrPolygonTooSmall if no candidate falls inside after many draws, as
+// happens for slivers that cover a vanishing share of their bounding box.
+func (g geo) PointInPolygon(poly Polygon) (LatLng, error) {
+	b, err := poly.bounds()
+	if err != nil {
+		return LatLng{}, err
+	}
+	rng := g.r.source()
+	for range maxPolygonTries {
+		if p := pointInBox(b, rng); poly.Contains(p) {
+			return p, nil
+		}
+	}
+	return LatLng{}, ErrPolygonTooSmall
+}
+
+func radians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+func degrees(rad float64) float64 {
+	return rad * 180 / math.Pi
+}
+
+// wrapLng maps a longitude to [-180, 180).
+func wrapLng(lng float64) float64 {
+	lng = math.Mod(lng+180, 360)
+	if lng < 0 {
+		lng += 360
+	}
+	return lng - 180
+}
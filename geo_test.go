@@ -0,0 +1,143 @@
+package randomizer_test
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"testing"
+
+	"github.com/colduction/randomizer"
+)
+
+func TestGeoPointUniformOnSphere(t *testing.T) {
+	const n = 20000
+	polar := 0
+	for range n {
+		p := randomizer.Geo.Point()
+		if !p.Valid() || p.Lng == 180 {
+			t.Fatalf("Point() = %v out of range", p)
+		}
+		if math.Abs(p.Lat) > 60 {
+			polar++
+		}
+	}
+	// Above 60° lies 1 - sin(60°) ≈ 13.4% of the sphere, not the 33% that a
+	// uniform latitude would give.
+	if frac := float64(polar) / n; math.Abs(frac-(1-math.Sqrt(3)/2)) > 0.02 {
+		t.Fatalf("%.3f of points above 60°, want about 0.134", frac)
+	}
+}
+
+func TestGeoPointInBox(t *testing.T) {
+	box := randomizer.BoundingBox{MinLat: 0, MinLng: 170, MaxLat: 90, MaxLng: -170}
+	const n = 20000
+	high := 0
+	for range n {
+		p, err := randomizer.Geo.PointInBox(box)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if p.Lat < 0 || p.Lat > 90 || (p.Lng < 170 && p.Lng >= -170) {
+			t.Fatalf("PointInBox = %v outside the antimeridian box", p)
+		}
+		if p.Lat > 30 {
+			high++
+		}
+	}
+	if frac := float64(high) / n; math.Abs(frac-0.5) > 0.02 {
+		t.Fatalf("%.3f of points above 30°, want about half by area", frac)
+	}
+	for _, b := range []randomizer.BoundingBox{
+		{MinLat: 10, MaxLat: 0},
+		{MinLat: -91, MaxLat: 0},
+		{MinLng: -181},
+		{MaxLng: math.NaN()},
+	} {
+		if _, err := randomizer.Geo.PointInBox(b); !errors.Is(err, randomizer.ErrInvalidBoundingBox) {
+			t.Errorf("%+v: err = %v, want ErrInvalidBoundingBox", b, err)
+		}
+	}
+}
+
+func TestGeoPointInRadius(t *testing.T) {
+	const radius = 50000.0
+	for _, center := range []randomizer.LatLng{
+		{Lat: 52.52, Lng: 13.405},
+		{Lat: 89.9, Lng: 0},
+		{Lat: -10, Lng: 179.9},
+	} {
+		var dists []float64
+		for range 4000 {
+			p, err := randomizer.Geo.PointInRadius(center, radius)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !p.Valid() {
+				t.Fatalf("PointInRadius(%v) = %v out of range", center, p)
+			}
+			d := center.DistanceTo(p)
+			if d > radius*(1+1e-9) {
+				t.Fatalf("PointInRadius(%v) = %v is %.1fm away", center, p, d)
+			}
+			dists = append(dists, d)
+		}
+		// Uniform by area, half of the points lie within r/√2.
+		sort.Float64s(dists)
+		if median := dists[len(dists)/2]; math.Abs(median-radius/math.Sqrt2) > radius*0.03 {
+			t.Fatalf("center %v: median distance %.0fm, want about %.0fm", center, median, radius/math.Sqrt2)
+		}
+	}
+	if _, err := randomizer.Geo.PointInRadius(randomizer.LatLng{}, 1e12); err != nil {
+		t.Fatalf("whole-sphere radius: %v", err)
+	}
+	if _, err := randomizer.Geo.PointInRadius(randomizer.LatLng{}, -1); !errors.Is(err, randomizer.ErrInvalidRadius) {
+		t.Fatalf("err = %v, want ErrInvalidRadius", err)
+	}
+	if _, err := randomizer.Geo.PointInRadius(randomizer.LatLng{Lat: 91}, 1); !errors.Is(err, randomizer.ErrInvalidCoordinate) {
+		t.Fatalf("err = %v, want ErrInvalidCoordinate", err)
+	}
+}
+
+func TestGeoPointInPolygon(t *testing.T) {
+	poly := randomizer.Polygon{
+		{{Lat: 0, Lng: 0}, {Lat: 0, Lng: 10}, {Lat: 10, Lng: 10}, {Lat: 10, Lng: 0}, {Lat: 0, Lng: 0}},
+		{{Lat: 2, Lng: 2}, {Lat: 8, Lng: 2}, {Lat: 8, Lng: 8}, {Lat: 2, Lng: 8}, {Lat: 2, Lng: 2}},
+	}
+	for range 2000 {
+		p, err := randomizer.Geo.PointInPolygon(poly)
+		if err != nil {
+			t.Fatal(err)
+		}
+		inHole := p.Lat > 2 && p.Lat < 8 && p.Lng > 2 && p.Lng < 8
+		if p.Lat < 0 || p.Lat > 10 || p.Lng < 0 || p.Lng > 10 || inHole || !poly.Contains(p) {
+			t.Fatalf("PointInPolygon = %v outside the polygon", p)
+		}
+	}
+	for name, bad := range map[string]randomizer.Polygon{
+		"empty":     nil,
+		"open ring": {{{0, 0}, {0, 1}, {1, 1}, {1, 0}}},
+		"too short": {{{0, 0}, {1, 1}, {0, 0}}},
+		"no area":   {{{0, 0}, {1, 1}, {2, 2}, {0, 0}}},
+		"invalid":   {{{0, 0}, {0, 200}, {1, 1}, {0, 0}}},
+	} {
+		if _, err := randomizer.Geo.PointInPolygon(bad); !errors.Is(err, randomizer.ErrInvalidPolygon) {
+			t.Errorf("%s: err = %v, want ErrInvalidPolygon", name, err)
+		}
+	}
+	// A hole covering the whole exterior leaves nothing to sample.
+	square := []randomizer.LatLng{{Lat: 0, Lng: 0}, {Lat: 0, Lng: 1}, {Lat: 1, Lng: 1}, {Lat: 1, Lng: 0}, {Lat: 0, Lng: 0}}
+	if _, err := randomizer.Geo.PointInPolygon(randomizer.Polygon{square, square}); !errors.Is(err, randomizer.ErrPolygonTooSmall) {
+		t.Errorf("covered polygon: err = %v, want ErrPolygonTooSmall", err)
+	}
+}
+
+func TestLatLngDistanceTo(t *testing.T) {
+	paris := randomizer.LatLng{Lat: 48.8566, Lng: 2.3522}
+	london := randomizer.LatLng{Lat: 51.5074, Lng: -0.1278}
+	if d := paris.DistanceTo(london); math.Abs(d-343.5e3) > 1e3 {
+		t.Fatalf("Paris-London = %.0fm, want about 343.5km", d)
+	}
+	if got := london.String(); got != "51.5074,-0.1278" {
+		t.Fatalf("String() = %q", got)
+	}
+}
@@ -0,0 +1,143 @@
+package randomizer
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+)
+
+// ErrInvalidGeoJSON is returned by ParseGeoJSONPolygon for input that is not
+// a GeoJSON Polygon geometry or a Feature holding one.
+var ErrInvalidGeoJSON = errors.New("randomizer: invalid GeoJSON polygon")
+
+// MaxGeohashPrecision is the longest geohash Geohash produces; 12 characters
+// resolve a point to a few centimeters.
+const MaxGeohashPrecision = 12
+
+const geohashAlphabet = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// Geohash encodes p as a geohash of the given number of characters, clamped
+// to [1, MaxGeohashPrecision].
+// ref: https://en.wikipedia.org/wiki/Geohash
+func (p LatLng) Geohash(precision int) string {
+	precision = min(max(precision, 1), MaxGeohashPrecision)
+	latLo, latHi := -90.0, 90.0
+	lngLo, lngHi := -180.0, 180.0
+	out := make([]byte, precision)
+	even := true // bits alternate starting with longitude
+	for i := range out {
+		var c byte
+		for range 5 {
+			c <<= 1
+			if even {
+				if mid := (lngLo + lngHi) / 2; p.Lng >= mid {
+					c |= 1
+					lngLo = mid
+				} else {
+					lngHi = mid
+				}
+			} else {
+				if mid := (latLo + latHi) / 2; p.Lat >= mid {
+					c |= 1
+					latLo = mid
+				} else {
+					latHi = mid
+				}
+			}
+			even = !even
+		}
+		out[i] = geohashAlphabet[c]
+	}
+	return string(out)
+}
+
+// GeoJSON returns p as a GeoJSON Point geometry. Positions are written
+// longitude first, as RFC 7946 requires.
+// ref: https://www.rfc-editor.org/rfc/rfc7946#section-3.1.2
+func (p LatLng) GeoJSON() []byte {
+	return appendGeoJSONPoint(nil, p)
+}
+
+func appendGeoJSONPoint(b []byte, p LatLng) []byte {
+	b = append(b, `{"type":"Point","coordinates":`...)
+	return append(appendGeoJSONPosition(b, p), '}')
+}
+
+func appendGeoJSONPosition(b []byte, p LatLng) []byte {
+	b = append(b, '[')
+	b = strconv.AppendFloat(b, p.Lng, 'f', -1, 64)
+	b = append(b, ',')
+	b = strconv.AppendFloat(b, p.Lat, 'f', -1, 64)
+	return append(b, ']')
+}
+
+// WriteGeoJSON writes points as a GeoJSON FeatureCollection of Point
+// features, each with its geohash at MaxGeohashPrecision as the "geohash"
+// property.
+func WriteGeoJSON(w io.Writer, points []LatLng) error {
+	bw := bufio.NewWriter(w)
+	buf := []byte(`{"type":"FeatureCollection","features":[`)
+	for i, p := range points {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		buf = append(buf, `{"type":"Feature","geometry":`...)
+		buf = appendGeoJSONPoint(buf, p)
+		buf = append(buf, `,"properties":{"geohash":"`...)
+		buf = append(buf, p.Geohash(MaxGeohashPrecision)...)
+		buf = append(buf, `"}}`...)
+		if _, err := bw.Write(buf); err != nil {
+			return err
+		}
+		buf = buf[:0]
+	}
+	buf = append(buf, "]}\n"...)
+	if _, err := bw.Write(buf); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// ParseGeoJSONPolygon parses a GeoJSON Polygon geometry, or a Feature whose
+// geometry is a Polygon, for use with Geo.PointInPolygon. Altitudes are
+// ignored.
+func ParseGeoJSONPolygon(data []byte) (Polygon, error) {
+	var obj struct {
+		Type        string          `json:"type"`
+		Coordinates json.RawMessage `json:"coordinates"`
+		Geometry    json.RawMessage `json:"geometry"`
+	}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, err
+	}
+	switch obj.Type {
+	case "Feature":
+		if obj.Geometry == nil {
+			return nil, ErrInvalidGeoJSON
+		}
+		return ParseGeoJSONPolygon(obj.Geometry)
+	case "Polygon":
+	default:
+		return nil, ErrInvalidGeoJSON
+	}
+	var coords [][][]float64
+	if err := json.Unmarshal(obj.Coordinates, &coords); err != nil {
+		return nil, ErrInvalidGeoJSON
+	}
+	poly := make(Polygon, len(coords))
+	for i, ring := range coords {
+		poly[i] = make([]LatLng, len(ring))
+		for j, pos := range ring {
+			if len(pos) < 2 {
+				return nil, ErrInvalidGeoJSON
+			}
+			poly[i][j] = LatLng{Lat: pos[1], Lng: pos[0]}
+		}
+	}
+	if _, err := poly.bounds(); err != nil {
+		return nil, err
+	}
+	return poly, nil
+}
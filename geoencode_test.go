@@ -0,0 +1,107 @@
+package randomizer_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/colduction/randomizer"
+)
+
+func TestGeohash(t *testing.T) {
+	p := randomizer.LatLng{Lat: 57.64911, Lng: 10.40744}
+	for _, tc := range []struct {
+		precision int
+		want      string
+	}{
+		{11, "u4pruydqqvj"},
+		{5, "u4pru"},
+		{0, "u"},
+		{99, "u4pruydqqvj8"},
+	} {
+		if got := p.Geohash(tc.precision); got != tc.want {
+			t.Errorf("Geohash(%d) = %q, want %q", tc.precision, got, tc.want)
+		}
+	}
+	if got := (randomizer.LatLng{Lat: -90, Lng: -180}).Geohash(4); got != "0000" {
+		t.Errorf("south-west corner = %q, want 0000", got)
+	}
+}
+
+func TestGeoJSON(t *testing.T) {
+	p := randomizer.LatLng{Lat: 51.5, Lng: -0.125}
+	if got, want := string(p.GeoJSON()), `{"type":"Point","coordinates":[-0.125,51.5]}`; got != want {
+		t.Fatalf("GeoJSON() = %s, want %s", got, want)
+	}
+
+	points := []randomizer.LatLng{p, randomizer.Geo.Point(), randomizer.Geo.Point()}
+	var buf bytes.Buffer
+	if err := randomizer.WriteGeoJSON(&buf, points); err != nil {
+		t.Fatal(err)
+	}
+	var fc struct {
+		Type     string `json:"type"`
+		Features []struct {
+			Type     string `json:"type"`
+			Geometry struct {
+				Type        string     `json:"type"`
+				Coordinates [2]float64 `json:"coordinates"`
+			} `json:"geometry"`
+			Properties struct {
+				Geohash string `json:"geohash"`
+			} `json:"properties"`
+		} `json:"features"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &fc); err != nil {
+		t.Fatalf("invalid JSON %s: %v", buf.Bytes(), err)
+	}
+	if fc.Type != "FeatureCollection" || len(fc.Features) != len(points) {
+		t.Fatalf("unexpected collection %s", buf.Bytes())
+	}
+	for i, f := range fc.Features {
+		got := randomizer.LatLng{Lat: f.Geometry.Coordinates[1], Lng: f.Geometry.Coordinates[0]}
+		if f.Type != "Feature" || f.Geometry.Type != "Point" || got != points[i] || f.Properties.Geohash != points[i].Geohash(12) {
+			t.Fatalf("feature %d = %+v, want %v", i, f, points[i])
+		}
+	}
+
+	buf.Reset()
+	if err := randomizer.WriteGeoJSON(&buf, nil); err != nil || buf.String() != "{\"type\":\"FeatureCollection\",\"features\":[]}\n" {
+		t.Fatalf("empty collection = %q, %v", buf.String(), err)
+	}
+}
+
+func TestParseGeoJSONPolygon(t *testing.T) {
+	feature := []byte(`{"type":"Feature","properties":{},"geometry":{"type":"Polygon","coordinates":[
+		[[13.0,52.3,34],[13.8,52.3],[13.8,52.7],[13.0,52.7],[13.0,52.3,34]]]}}`)
+	poly, err := randomizer.ParseGeoJSONPolygon(feature)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(poly) != 1 || len(poly[0]) != 5 || poly[0][1] != (randomizer.LatLng{Lat: 52.3, Lng: 13.8}) {
+		t.Fatalf("parsed %v", poly)
+	}
+	for range 100 {
+		p, err := randomizer.Geo.PointInPolygon(poly)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if p.Lat < 52.3 || p.Lat > 52.7 || p.Lng < 13 || p.Lng > 13.8 {
+			t.Fatalf("point %v outside the parsed polygon", p)
+		}
+	}
+
+	for _, in := range []string{
+		`{"type":"Point","coordinates":[1,2]}`,
+		`{"type":"Feature"}`,
+		`{"type":"Polygon","coordinates":[[[1],[2],[3],[1]]]}`,
+	} {
+		if _, err := randomizer.ParseGeoJSONPolygon([]byte(in)); !errors.Is(err, randomizer.ErrInvalidGeoJSON) {
+			t.Errorf("%s: err = %v, want ErrInvalidGeoJSON", in, err)
+		}
+	}
+	if _, err := randomizer.ParseGeoJSONPolygon([]byte(`{"type":"Polygon","coordinates":[[[0,0],[1,1],[0,0]]]}`)); !errors.Is(err, randomizer.ErrInvalidPolygon) {
+		t.Errorf("short ring: err = %v, want ErrInvalidPolygon", err)
+	}
+}
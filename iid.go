@@ -0,0 +1,139 @@
+package randomizer
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"net/netip"
+)
+
+var (
+	// ErrInvalidHardwareAddr is returned when a MAC is neither EUI-48 nor EUI-64.
+	ErrInvalidHardwareAddr = errors.New("randomizer: hardware address is not EUI-48 or EUI-64")
+	// ErrNotIPv6Prefix is returned when an IPv6 prefix is required.
+	ErrNotIPv6Prefix = errors.New("randomizer: prefix is not a valid IPv6 prefix")
+)
+
+// maxStableIIDAttempts bounds how many DAD counter values StableIID tries
+// before accepting a reserved identifier; hitting it is astronomically unlikely.
+const maxStableIIDAttempts = 256
+
+// isReservedIID reports whether iid is reserved by RFC 5453.
+// ref: https://datatracker.ietf.org/doc/html/rfc5453#section-3
+func isReservedIID(iid uint64) bool {
+	switch {
+	case iid == 0:
+		// Subnet-Router Anycast.
+		return true
+	case iid >= 0x02005EFFFE000000 && iid <= 0x02005EFFFEFFFFFF:
+		// Reserved IPv6 Interface Identifiers corresponding to the IANA Ethernet Block.
+		return true
+	case iid >= 0xFDFFFFFFFFFFFF80 && iid <= 0xFDFFFFFFFFFFFFFF:
+		// Reserved Subnet Anycast Addresses.
+		return true
+	}
+	return false
+}
+
+// ModifiedEUI64 returns the modified EUI-64 interface identifier of a 48-bit
+// MAC or 64-bit EUI-64, inserting FF:FE into EUI-48 addresses and inverting
+// the universal/local bit.
+// ref: https://datatracker.ietf.org/doc/html/rfc4291#appendix-A
+func (network) ModifiedEUI64(mac net.HardwareAddr) ([8]byte, error) {
+	var iid [8]byte
+	switch len(mac) {
+	case 6:
+		copy(iid[:3], mac[:3])
+		iid[3], iid[4] = 0xFF, 0xFE
+		copy(iid[5:], mac[3:])
+	case 8:
+		copy(iid[:], mac)
+	default:
+		return iid, ErrInvalidHardwareAddr
+	}
+	iid[0] ^= 0x02
+	return iid, nil
+}
+
+// StableIID returns an RFC 7217 stable, semantically opaque interface
+// identifier: the same prefix, interface, network and secret key always yield
+// the same identifier, while different prefixes yield unrelated ones. netIface
+// names the interface, networkID may be empty, and dadCounter is incremented
+// by the caller after a duplicate address detection failure. The pseudorandom
+// function is HMAC-SHA-256 keyed with secret over the length-prefixed inputs,
+// and identifiers reserved by RFC 5453 are skipped by bumping the counter.
+// ref: https://datatracker.ietf.org/doc/html/rfc7217#section-5
+func (network) StableIID(prefix netip.Prefix, netIface string, networkID []byte, dadCounter uint8, secret []byte) ([8]byte, error) {
+	if !prefix.IsValid() || !prefix.Addr().Is6() {
+		return [8]byte{}, ErrNotIPv6Prefix
+	}
+	p := prefix.Masked().Addr().As16()
+	var sum [sha256.Size]byte
+	for attempt := range maxStableIIDAttempts {
+		mac := hmac.New(sha256.New, secret)
+		mac.Write(p[:8])
+		writeLengthPrefixed(mac, []byte(netIface))
+		writeLengthPrefixed(mac, networkID)
+		mac.Write([]byte{dadCounter + uint8(attempt)})
+		mac.Sum(sum[:0])
+		if !isReservedIID(binary.BigEndian.Uint64(sum[:8])) {
+			break
+		}
+	}
+	return [8]byte(sum[:8]), nil
+}
+
+func writeLengthPrefixed(w io.Writer, b []byte) {
+	var n [4]byte
+	binary.BigEndian.PutUint32(n[:], uint32(len(b)))
+	w.Write(n[:])
+	w.Write(b)
+}
+
+// TemporaryIID returns an RFC 8981 temporary interface identifier: 64 random
+// bits that avoid the identifiers reserved by RFC 5453.
+// ref: https://datatracker.ietf.org/doc/html/rfc8981#section-3.3.1
+func (n network) TemporaryIID() [8]byte {
+	rng := n.r.source()
+	var iid [8]byte
+	for {
+		x := rng.next64()
+		if !isReservedIID(x) {
+			binary.BigEndian.PutUint64(iid[:], x)
+			return iid
+		}
+	}
+}
+
+// Addr6FromIID returns the address formed by the upper 64 bits of prefix and
+// the interface identifier iid. Host bits set in prefix are ignored.
+func (network) Addr6FromIID(prefix netip.Prefix, iid [8]byte) (netip.Addr, error) {
+	if !prefix.IsValid() || !prefix.Addr().Is6() {
+		return netip.Addr{}, ErrNotIPv6Prefix
+	}
+	b := prefix.Masked().Addr().As16()
+	copy(b[8:], iid[:])
+	return netip.AddrFrom16(b), nil
+}
+
+// Addr6UnicastIID generates an IPv6 unicast address of the specified type
+// whose lower 64 bits are iid. The upper 64 bits are random within the type's
+// prefix, except for LinkLocalType, which uses fe80::/64 as RFC 4291 requires.
+func (n network) Addr6UnicastIID(unicastType UnicastType, iid [8]byte) netip.Addr {
+	var b [net.IPv6len]byte
+	if unicastType == LinkLocalType {
+		b[0], b[1] = 0xFE, 0x80
+	} else {
+		b = n.Addr6Unicast(unicastType).As16()
+	}
+	copy(b[8:], iid[:])
+	return netip.AddrFrom16(b)
+}
+
+// Addr6Temporary generates an RFC 8981 temporary address in the /64 of prefix.
+func (n network) Addr6Temporary(prefix netip.Prefix) (netip.Addr, error) {
+	return n.Addr6FromIID(prefix, n.TemporaryIID())
+}
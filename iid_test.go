@@ -0,0 +1,106 @@
+package randomizer_test
+
+import (
+	"encoding/binary"
+	"net"
+	"net/netip"
+	"testing"
+
+	"github.com/colduction/randomizer"
+)
+
+func TestNetworkModifiedEUI64(t *testing.T) {
+	mac := net.HardwareAddr{0x00, 0x1B, 0x63, 0x84, 0x45, 0xE6}
+	iid, err := randomizer.Network.ModifiedEUI64(mac)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := [8]byte{0x02, 0x1B, 0x63, 0xFF, 0xFE, 0x84, 0x45, 0xE6}; iid != want {
+		t.Fatalf("ModifiedEUI64(%v) = %x, want %x", mac, iid, want)
+	}
+	a := randomizer.Network.Addr6UnicastIID(randomizer.LinkLocalType, iid)
+	if want := netip.MustParseAddr("fe80::21b:63ff:fe84:45e6"); a != want {
+		t.Fatalf("Addr6UnicastIID(LinkLocalType) = %v, want %v", a, want)
+	}
+	if _, err := randomizer.Network.ModifiedEUI64(mac[:4]); err != randomizer.ErrInvalidHardwareAddr {
+		t.Fatalf("ModifiedEUI64 with 4 bytes error = %v, want ErrInvalidHardwareAddr", err)
+	}
+}
+
+func TestNetworkStableIID(t *testing.T) {
+	secret := []byte("0123456789abcdef")
+	p1 := netip.MustParsePrefix("2001:db8:1::/64")
+	p2 := netip.MustParsePrefix("2001:db8:2::/64")
+
+	a, err := randomizer.Network.StableIID(p1, "eth0", nil, 0, secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := randomizer.Network.StableIID(p1, "eth0", nil, 0, secret)
+	if a != b {
+		t.Fatal("StableIID is not stable for identical inputs")
+	}
+	for _, other := range [][8]byte{
+		must8(randomizer.Network.StableIID(p2, "eth0", nil, 0, secret)),
+		must8(randomizer.Network.StableIID(p1, "eth1", nil, 0, secret)),
+		must8(randomizer.Network.StableIID(p1, "eth0", []byte("ssid"), 0, secret)),
+		must8(randomizer.Network.StableIID(p1, "eth0", nil, 1, secret)),
+		must8(randomizer.Network.StableIID(p1, "eth0", nil, 0, []byte("other secret"))),
+	} {
+		if other == a {
+			t.Fatal("StableIID did not change with its inputs")
+		}
+	}
+	if c := must8(randomizer.Network.StableIID(netip.MustParsePrefix("2001:db8:1::5/64"), "eth0", nil, 0, secret)); c != a {
+		t.Fatal("StableIID depends on host bits of the prefix")
+	}
+	if _, err := randomizer.Network.StableIID(netip.MustParsePrefix("10.0.0.0/8"), "eth0", nil, 0, secret); err != randomizer.ErrNotIPv6Prefix {
+		t.Fatalf("StableIID with IPv4 prefix error = %v, want ErrNotIPv6Prefix", err)
+	}
+}
+
+func TestNetworkTemporaryAddr(t *testing.T) {
+	p := netip.MustParsePrefix("2001:db8:aa:bb::/64")
+	seen := make(map[netip.Addr]bool)
+	for range 1000 {
+		a, err := randomizer.Network.Addr6Temporary(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !p.Contains(a) || seen[a] {
+			t.Fatalf("Addr6Temporary returned %v", a)
+		}
+		seen[a] = true
+		iid := binary.BigEndian.Uint64(a.AsSlice()[8:])
+		if iid == 0 || iid>>7 == 0xFDFFFFFFFFFFFF80>>7 {
+			t.Fatalf("Addr6Temporary returned reserved identifier %v", a)
+		}
+	}
+	iid := [8]byte{0, 0, 0, 0, 0, 0, 0, 1}
+	a, err := randomizer.Network.Addr6FromIID(netip.MustParsePrefix("2001:db8:0:ffff::5/48"), iid)
+	if err != nil || a != netip.MustParseAddr("2001:db8::1") {
+		t.Fatalf("Addr6FromIID with host bits set = %v, %v; want 2001:db8::1", a, err)
+	}
+	ula := randomizer.Network.Addr6UnicastIID(randomizer.UniqueLocalType, randomizer.Network.TemporaryIID())
+	if ula.As16()[0] != 0xFD {
+		t.Fatalf("Addr6UnicastIID(UniqueLocalType) = %v", ula)
+	}
+}
+
+func TestNetworkTemporaryIIDHighBytes(t *testing.T) {
+	// About one identifier in 128 starts with 0xFE or 0xFF; only the top of
+	// the 0xFD range is reserved.
+	for range 100000 {
+		if iid := randomizer.Network.TemporaryIID(); iid[0] >= 0xFE {
+			return
+		}
+	}
+	t.Fatal("TemporaryIID never returned an identifier starting with 0xFE or 0xFF")
+}
+
+func must8(iid [8]byte, err error) [8]byte {
+	if err != nil {
+		panic(err)
+	}
+	return iid
+}
@@ -0,0 +1,101 @@
+package randomizer
+
+import (
+	"net"
+	"net/netip"
+)
+
+// IPv4Category selects a class of IPv4 addresses, mirroring UnicastType for IPv6.
+type IPv4Category uint8
+
+const (
+	// IPv4Public is globally routable unicast space outside every IANA
+	// special-purpose block and outside multicast.
+	IPv4Public IPv4Category = iota + 1
+	// IPv4Private is RFC 1918 space: 10/8, 172.16/12 and 192.168/16.
+	IPv4Private
+	// IPv4Shared is RFC 6598 carrier-grade NAT space: 100.64/10.
+	IPv4Shared
+	// IPv4Loopback is 127/8.
+	IPv4Loopback
+	// IPv4LinkLocal is RFC 3927 space: 169.254/16.
+	IPv4LinkLocal
+	// IPv4Documentation is RFC 5737 TEST-NET-1, TEST-NET-2 and TEST-NET-3.
+	IPv4Documentation
+	// IPv4Multicast is 224/4 of any scope.
+	IPv4Multicast
+	IPv4CGNAT IPv4Category = IPv4Shared
+)
+
+var ipv4MulticastPrefix = netip.MustParsePrefix("224.0.0.0/4")
+
+var ipv4Categories = [...]addrSpace{
+	IPv4Public: newAddrSpace(
+		[]netip.Prefix{netip.MustParsePrefix("0.0.0.0/0")},
+		append([]netip.Prefix{ipv4MulticastPrefix}, ipv4SpecialPurpose...),
+	),
+	IPv4Private: newAddrSpace([]netip.Prefix{
+		netip.MustParsePrefix("10.0.0.0/8"),
+		netip.MustParsePrefix("172.16.0.0/12"),
+		netip.MustParsePrefix("192.168.0.0/16"),
+	}, nil),
+	IPv4Shared:    newAddrSpace([]netip.Prefix{netip.MustParsePrefix("100.64.0.0/10")}, nil),
+	IPv4Loopback:  newAddrSpace([]netip.Prefix{netip.MustParsePrefix("127.0.0.0/8")}, nil),
+	IPv4LinkLocal: newAddrSpace([]netip.Prefix{netip.MustParsePrefix("169.254.0.0/16")}, nil),
+	IPv4Documentation: newAddrSpace([]netip.Prefix{
+		netip.MustParsePrefix("192.0.2.0/24"),
+		netip.MustParsePrefix("198.51.100.0/24"),
+		netip.MustParsePrefix("203.0.113.0/24"),
+	}, nil),
+	IPv4Multicast: newAddrSpace([]netip.Prefix{ipv4MulticastPrefix}, nil),
+}
+
+// ipv4MulticastScopes maps IPv6 multicast scopes to their closest IPv4 ranges.
+// ref: https://datatracker.ietf.org/doc/html/rfc2365
+// ref: https://datatracker.ietf.org/doc/html/rfc5771
+var ipv4MulticastScopes = map[MulticastScope]addrSpace{
+	InterfaceLocalScope: newAddrSpace([]netip.Prefix{netip.MustParsePrefix("224.0.0.0/24")}, nil),
+	LinkLocalScope:      newAddrSpace([]netip.Prefix{netip.MustParsePrefix("224.0.0.0/24")}, nil),
+	AdminLocalScope:     newAddrSpace([]netip.Prefix{netip.MustParsePrefix("239.0.0.0/8")}, nil),
+	SiteLocalScope:      newAddrSpace([]netip.Prefix{netip.MustParsePrefix("239.255.0.0/16")}, nil),
+	OrgLocalScope:       newAddrSpace([]netip.Prefix{netip.MustParsePrefix("239.192.0.0/14")}, nil),
+	GlobalScope: newAddrSpace(
+		[]netip.Prefix{netip.MustParsePrefix("224.0.0.0/4")},
+		[]netip.Prefix{netip.MustParsePrefix("224.0.0.0/24"), netip.MustParsePrefix("239.0.0.0/8")},
+	),
+}
+
+// Addr4Category generates a random IPv4 address within the specified category,
+// chosen uniformly among the category's addresses. An unknown category yields
+// an unconstrained address, as Addr4 does.
+func (n network) Addr4Category(category IPv4Category) netip.Addr {
+	if category == 0 || int(category) >= len(ipv4Categories) {
+		return n.Addr4()
+	}
+	rng := n.r.source()
+	return ipv4Categories[category].pick(rng)
+}
+
+// Addr4Multicast generates a random IPv4 multicast address in the range
+// matching scope: 224.0.0.0/24 for interface- and link-local, 239.255.0.0/16
+// for site-local, 239.192.0.0/14 for organization-local, 239.0.0.0/8 for
+// admin-local and the remaining 224.0.0.0/4 space for global scope.
+// An unknown scope yields any multicast address.
+func (n network) Addr4Multicast(scope MulticastScope) netip.Addr {
+	space, ok := ipv4MulticastScopes[scope]
+	if !ok {
+		space = ipv4Categories[IPv4Multicast]
+	}
+	rng := n.r.source()
+	return space.pick(rng)
+}
+
+// IPv4CategoryAddr generates a random IPv4 address within the specified category.
+func (n network) IPv4CategoryAddr(category IPv4Category) net.IP {
+	return net.IP(n.Addr4Category(category).AsSlice())
+}
+
+// IPv4MulticastAddr generates a random IPv4 multicast address with the specified scope.
+func (n network) IPv4MulticastAddr(scope MulticastScope) net.IP {
+	return net.IP(n.Addr4Multicast(scope).AsSlice())
+}
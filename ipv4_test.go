@@ -0,0 +1,88 @@
+package randomizer_test
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/colduction/randomizer"
+)
+
+func TestNetworkAddr4Category(t *testing.T) {
+	cases := []struct {
+		category randomizer.IPv4Category
+		prefixes []string
+	}{
+		{randomizer.IPv4Private, []string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16"}},
+		{randomizer.IPv4CGNAT, []string{"100.64.0.0/10"}},
+		{randomizer.IPv4Loopback, []string{"127.0.0.0/8"}},
+		{randomizer.IPv4LinkLocal, []string{"169.254.0.0/16"}},
+		{randomizer.IPv4Documentation, []string{"192.0.2.0/24", "198.51.100.0/24", "203.0.113.0/24"}},
+		{randomizer.IPv4Multicast, []string{"224.0.0.0/4"}},
+	}
+	for _, tc := range cases {
+		for range 1000 {
+			a := randomizer.Network.Addr4Category(tc.category)
+			if !inAnyPrefix(a, tc.prefixes) {
+				t.Fatalf("Addr4Category(%d) returned %v outside %v", tc.category, a, tc.prefixes)
+			}
+		}
+	}
+}
+
+func TestNetworkAddr4CategoryPublic(t *testing.T) {
+	special := []string{
+		"0.0.0.0/8", "10.0.0.0/8", "100.64.0.0/10", "127.0.0.0/8", "169.254.0.0/16",
+		"172.16.0.0/12", "192.0.0.0/24", "192.0.2.0/24", "192.88.99.0/24", "192.168.0.0/16",
+		"198.18.0.0/15", "198.51.100.0/24", "203.0.113.0/24", "224.0.0.0/4", "240.0.0.0/4",
+	}
+	for range 10000 {
+		a := randomizer.Network.Addr4Category(randomizer.IPv4Public)
+		if !a.Is4() || inAnyPrefix(a, special) || !a.IsGlobalUnicast() {
+			t.Fatalf("Addr4Category(IPv4Public) returned non-public %v", a)
+		}
+	}
+	if ip := randomizer.Network.IPv4CategoryAddr(randomizer.IPv4Private); len(ip) != 4 || !ip.IsPrivate() {
+		t.Fatalf("IPv4CategoryAddr(IPv4Private) returned %v", ip)
+	}
+}
+
+func TestNetworkAddr4Multicast(t *testing.T) {
+	cases := map[randomizer.MulticastScope]string{
+		randomizer.LinkLocalScope:  "224.0.0.0/24",
+		randomizer.SiteLocalScope:  "239.255.0.0/16",
+		randomizer.OrgLocalScope:   "239.192.0.0/14",
+		randomizer.AdminLocalScope: "239.0.0.0/8",
+	}
+	for scope, prefix := range cases {
+		for range 1000 {
+			if a := randomizer.Network.Addr4Multicast(scope); !inAnyPrefix(a, []string{prefix}) {
+				t.Fatalf("Addr4Multicast(%#x) returned %v outside %s", scope, a, prefix)
+			}
+		}
+	}
+	for range 1000 {
+		a := randomizer.Network.Addr4Multicast(randomizer.GlobalScope)
+		if !a.IsMulticast() || inAnyPrefix(a, []string{"224.0.0.0/24", "239.0.0.0/8"}) {
+			t.Fatalf("Addr4Multicast(GlobalScope) returned %v", a)
+		}
+	}
+	if ip := randomizer.Network.IPv4MulticastAddr(randomizer.GlobalScope); !ip.IsMulticast() {
+		t.Fatalf("IPv4MulticastAddr returned %v", ip)
+	}
+}
+
+func inAnyPrefix(a netip.Addr, prefixes []string) bool {
+	for _, p := range prefixes {
+		if netip.MustParsePrefix(p).Contains(a) {
+			return true
+		}
+	}
+	return false
+}
+
+func BenchmarkNetworkAddr4CategoryPublic(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		benchAddr = randomizer.Network.Addr4Category(randomizer.IPv4Public)
+	}
+}
@@ -0,0 +1,151 @@
+package randomizer
+
+import "strconv"
+
+// CardBrand identifies a payment card network by its IIN ranges.
+type CardBrand uint8
+
+const (
+	Visa CardBrand = iota + 1
+	Mastercard
+	Amex
+	Discover
+	JCB
+)
+
+// iinRange is an inclusive range of issuer identification number prefixes.
+type iinRange struct {
+	lo, hi uint32
+	digits uint8
+}
+
+type cardSpec struct {
+	ranges  []iinRange
+	lengths []uint8
+}
+
+// ref: https://en.wikipedia.org/wiki/Payment_card_number#Issuer_identification_number_(IIN)
+var cardSpecs = [...]cardSpec{
+	Visa: {
+		ranges:  []iinRange{{4, 4, 1}},
+		lengths: []uint8{13, 16, 19},
+	},
+	Mastercard: {
+		ranges:  []iinRange{{51, 55, 2}, {2221, 2720, 4}},
+		lengths: []uint8{16},
+	},
+	Amex: {
+		ranges:  []iinRange{{34, 34, 2}, {37, 37, 2}},
+		lengths: []uint8{15},
+	},
+	Discover: {
+		ranges:  []iinRange{{6011, 6011, 4}, {644, 649, 3}, {65, 65, 2}, {622126, 622925, 6}},
+		lengths: []uint8{16, 17, 18, 19},
+	},
+	JCB: {
+		ranges:  []iinRange{{3528, 3589, 4}},
+		lengths: []uint8{16, 17, 18, 19},
+	},
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// luhnCheckDigit returns the digit that makes payload followed by it Luhn-valid.
+func luhnCheckDigit(payload []byte) byte {
+	sum := 0
+	double := true
+	for i := len(payload) - 1; i >= 0; i-- {
+		d := int(payload[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return deci[(10-sum%10)%10]
+}
+
+// luhnFill fills out after prefix with random digits and a trailing Luhn check digit.
+func luhnFill(out []byte, prefix string, rng *wordRNG) {
+	n := copy(out, prefix)
+	fillDecimal(out[n:len(out)-1], rng)
+	out[len(out)-1] = luhnCheckDigit(out[:len(out)-1])
+}
+
+// Luhn generates a random numeric string of the specified length that starts
+// with prefix and ends with a valid Luhn check digit. Digits may repeat.
+// It returns an empty string if length is below 2, the shortest number
+// LuhnValid accepts, or if prefix contains non-digits or leaves no room for
+// the check digit.
+func (w word) Luhn(length int, prefix string) string {
+	if length < 2 || length <= len(prefix) || !isDigits(prefix) {
+		return ""
+	}
+	out := make([]byte, length)
+	rng := w.r.source()
+	luhnFill(out, prefix, rng)
+	return string(out)
+}
+
+// LuhnValid reports whether s is a string of at least two digits with a valid
+// Luhn (mod 10) check digit.
+func (word) LuhnValid(s string) bool {
+	if len(s) < 2 || !isDigits(s) {
+		return false
+	}
+	return luhnCheckDigit([]byte(s[:len(s)-1])) == s[len(s)-1]
+}
+
+// Card generates a random Luhn-valid card number for brand with an IIN prefix
+// and length drawn from the brand's published ranges. Prefix ranges are
+// weighted by the share of the number space they cover.
+// It returns an empty string for an unknown brand.
+func (w word) Card(brand CardBrand) string {
+	if brand == 0 || int(brand) >= len(cardSpecs) {
+		return ""
+	}
+	spec := &cardSpecs[brand]
+	rng := w.r.source()
+
+	var maxDigits uint8
+	for _, r := range spec.ranges {
+		maxDigits = max(maxDigits, r.digits)
+	}
+	var total uint64
+	for _, r := range spec.ranges {
+		total += uint64(r.hi-r.lo+1) * pow10(maxDigits-r.digits)
+	}
+	v := uniformUint64n(total, rng)
+	var prefix string
+	for _, r := range spec.ranges {
+		weight := uint64(r.hi-r.lo+1) * pow10(maxDigits-r.digits)
+		if v < weight {
+			prefix = strconv.FormatUint(uint64(r.lo)+v/pow10(maxDigits-r.digits), 10)
+			break
+		}
+		v -= weight
+	}
+
+	length := spec.lengths[uniformUint64n(uint64(len(spec.lengths)), rng)]
+	out := make([]byte, length)
+	luhnFill(out, prefix, rng)
+	return string(out)
+}
+
+func pow10(n uint8) uint64 {
+	p := uint64(1)
+	for range n {
+		p *= 10
+	}
+	return p
+}
@@ -0,0 +1,97 @@
+package randomizer_test
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/colduction/randomizer"
+)
+
+func TestWordLuhn(t *testing.T) {
+	for range 1000 {
+		s := randomizer.Word.Luhn(16, "4000")
+		if len(s) != 16 || !strings.HasPrefix(s, "4000") {
+			t.Fatalf("Luhn(16, \"4000\") = %q", s)
+		}
+		if !randomizer.Word.LuhnValid(s) {
+			t.Fatalf("Luhn generated invalid number %q", s)
+		}
+	}
+	if got := randomizer.Word.Luhn(4, "4000"); got != "" {
+		t.Fatalf("Luhn with no room for check digit = %q, want empty string", got)
+	}
+	if got := randomizer.Word.Luhn(1, ""); got != "" {
+		t.Fatalf("Luhn(1, \"\") = %q, want empty string", got)
+	}
+	if got := randomizer.Word.Luhn(2, ""); !randomizer.Word.LuhnValid(got) {
+		t.Fatalf("Luhn(2, \"\") = %q, want a valid number", got)
+	}
+	if got := randomizer.Word.Luhn(8, "4a"); got != "" {
+		t.Fatalf("Luhn with non-digit prefix = %q, want empty string", got)
+	}
+}
+
+func TestWordLuhnValid(t *testing.T) {
+	cases := map[string]bool{
+		"79927398713":      true,
+		"79927398710":      false,
+		"4111111111111111": true,
+		"4111111111111112": false,
+		"":                 false,
+		"0":                false,
+		"4111-1111":        false,
+	}
+	for in, want := range cases {
+		if got := randomizer.Word.LuhnValid(in); got != want {
+			t.Fatalf("LuhnValid(%q) = %t, want %t", in, got, want)
+		}
+	}
+}
+
+func TestWordCard(t *testing.T) {
+	cases := []struct {
+		brand   randomizer.CardBrand
+		lengths []int
+		valid   func(string) bool
+	}{
+		{randomizer.Visa, []int{13, 16, 19}, func(s string) bool { return s[0] == '4' }},
+		{randomizer.Mastercard, []int{16}, func(s string) bool {
+			p2, _ := strconv.Atoi(s[:2])
+			p4, _ := strconv.Atoi(s[:4])
+			return (p2 >= 51 && p2 <= 55) || (p4 >= 2221 && p4 <= 2720)
+		}},
+		{randomizer.Amex, []int{15}, func(s string) bool { return s[:2] == "34" || s[:2] == "37" }},
+		{randomizer.Discover, []int{16, 17, 18, 19}, func(s string) bool {
+			p3, _ := strconv.Atoi(s[:3])
+			p6, _ := strconv.Atoi(s[:6])
+			return s[:4] == "6011" || (p3 >= 644 && p3 <= 649) || s[:2] == "65" || (p6 >= 622126 && p6 <= 622925)
+		}},
+		{randomizer.JCB, []int{16, 17, 18, 19}, func(s string) bool {
+			p4, _ := strconv.Atoi(s[:4])
+			return p4 >= 3528 && p4 <= 3589
+		}},
+	}
+	for _, tc := range cases {
+		for range 500 {
+			s := randomizer.Word.Card(tc.brand)
+			okLen := false
+			for _, l := range tc.lengths {
+				okLen = okLen || len(s) == l
+			}
+			if !okLen || !tc.valid(s) || !randomizer.Word.LuhnValid(s) {
+				t.Fatalf("Card(%d) generated %q", tc.brand, s)
+			}
+		}
+	}
+	if got := randomizer.Word.Card(0); got != "" {
+		t.Fatalf("Card(0) = %q, want empty string", got)
+	}
+}
+
+func BenchmarkWordCard(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		benchWordString = randomizer.Word.Card(randomizer.Visa)
+	}
+}
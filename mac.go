@@ -0,0 +1,201 @@
+package randomizer
+
+import (
+	"errors"
+	"net"
+	"strings"
+)
+
+var (
+	// ErrInvalidMACPrefix is returned when a MAC prefix is longer than its
+	// bytes or than the address being generated.
+	ErrInvalidMACPrefix = errors.New("randomizer: invalid MAC prefix")
+	// ErrUnknownVendor is returned when no registry entry matches a vendor name.
+	ErrUnknownVendor = errors.New("randomizer: no OUI registered to vendor")
+)
+
+// Common MAC prefix lengths of IEEE registry assignments.
+const (
+	MALBits = 24 // MA-L, the classic OUI
+	MAMBits = 28 // MA-M
+	MASBits = 36 // MA-S, formerly OUI-36
+)
+
+// MACFormat selects the textual notation of a MAC address.
+type MACFormat uint8
+
+const (
+	// ColonFormat is the IEEE 802 notation, e.g. 00:1b:63:84:45:e6.
+	ColonFormat MACFormat = iota
+	// DashFormat is the IEEE 802 canonical notation, e.g. 00-1B-63-84-45-E6.
+	DashFormat
+	// DotFormat is the Cisco notation, e.g. 001b.6384.45e6.
+	DotFormat
+	// BareFormat is plain hexadecimal, e.g. 001b638445e6.
+	BareFormat
+)
+
+// ouiEntry is an IEEE registry assignment.
+type ouiEntry struct {
+	prefix [3]byte
+	vendor string
+}
+
+// ouiRegistry is a small snapshot of the IEEE MA-L registry covering common
+// hardware and virtualization vendors.
+// ref: https://standards-oui.ieee.org/oui/oui.txt
+var ouiRegistry = []ouiEntry{
+	{[3]byte{0x00, 0x00, 0x0C}, "Cisco Systems, Inc"},
+	{[3]byte{0x00, 0x1A, 0xA1}, "Cisco Systems, Inc"},
+	{[3]byte{0x00, 0x03, 0x93}, "Apple, Inc."},
+	{[3]byte{0x00, 0x0A, 0x95}, "Apple, Inc."},
+	{[3]byte{0x00, 0x1B, 0x63}, "Apple, Inc."},
+	{[3]byte{0x00, 0x1E, 0xC2}, "Apple, Inc."},
+	{[3]byte{0x00, 0x13, 0xE8}, "Intel Corporate"},
+	{[3]byte{0x00, 0x15, 0x17}, "Intel Corporate"},
+	{[3]byte{0x00, 0x1B, 0x21}, "Intel Corporate"},
+	{[3]byte{0x00, 0x14, 0x22}, "Dell Inc."},
+	{[3]byte{0x00, 0x21, 0x9B}, "Dell Inc."},
+	{[3]byte{0x00, 0x12, 0xFB}, "Samsung Electronics Co.,Ltd"},
+	{[3]byte{0x00, 0x16, 0x32}, "Samsung Electronics Co.,Ltd"},
+	{[3]byte{0x00, 0x05, 0x69}, "VMware, Inc."},
+	{[3]byte{0x00, 0x0C, 0x29}, "VMware, Inc."},
+	{[3]byte{0x00, 0x1C, 0x14}, "VMware, Inc."},
+	{[3]byte{0x00, 0x50, 0x56}, "VMware, Inc."},
+	{[3]byte{0x00, 0x03, 0xFF}, "Microsoft Corporation"},
+	{[3]byte{0x00, 0x15, 0x5D}, "Microsoft Corporation"},
+	{[3]byte{0x08, 0x00, 0x27}, "PCS Systemtechnik GmbH"},
+	{[3]byte{0x00, 0x16, 0x3E}, "Xensource, Inc."},
+	{[3]byte{0xB8, 0x27, 0xEB}, "Raspberry Pi Foundation"},
+	{[3]byte{0xDC, 0xA6, 0x32}, "Raspberry Pi Trading Ltd"},
+	{[3]byte{0x24, 0x0A, 0xC4}, "Espressif Inc."},
+	{[3]byte{0x30, 0xAE, 0xA4}, "Espressif Inc."},
+	{[3]byte{0x00, 0x1A, 0x11}, "Google, Inc."},
+	{[3]byte{0x00, 0xE0, 0xFC}, "HUAWEI TECHNOLOGIES CO.,LTD"},
+	{[3]byte{0x00, 0x18, 0x82}, "HUAWEI TECHNOLOGIES CO.,LTD"},
+	{[3]byte{0x00, 0x05, 0x85}, "Juniper Networks"},
+	{[3]byte{0x00, 0x10, 0x18}, "Broadcom"},
+	{[3]byte{0x00, 0xE0, 0x4C}, "REALTEK SEMICONDUCTOR CORP."},
+	{[3]byte{0x00, 0x25, 0x90}, "Super Micro Computer, Inc."},
+	{[3]byte{0x00, 0x1C, 0x73}, "Arista Networks"},
+	{[3]byte{0x00, 0x09, 0x5B}, "NETGEAR"},
+	{[3]byte{0x00, 0x00, 0x00}, "XEROX CORPORATION"},
+}
+
+// MACOptions configures Network.MAC.
+type MACOptions struct {
+	// Prefix holds the fixed leading bits of the address, such as an OUI.
+	Prefix []byte
+	// PrefixBits is the number of leading bits of Prefix to keep, typically
+	// MALBits, MAMBits or MASBits. Zero keeps all of Prefix.
+	PrefixBits int
+	// Vendor selects a random OUI registered to a vendor whose name contains
+	// Vendor, ignoring case. It is ignored when Prefix is set.
+	Vendor string
+	// EUI64 generates an 8-byte EUI-64 instead of a 6-byte EUI-48.
+	EUI64 bool
+	// Local and Multicast set the U/L and I/G bits when neither Prefix nor
+	// Vendor is given; otherwise those bits come from the prefix.
+	Local, Multicast bool
+}
+
+// MAC generates a random MAC address. A fixed prefix or a vendor OUI keeps the
+// leading bits of the address; the remaining bits are random. A nil opts
+// behaves like MACAddr(false, false).
+func (nw network) MAC(opts *MACOptions) (net.HardwareAddr, error) {
+	if opts == nil {
+		opts = new(MACOptions)
+	}
+	n := 6
+	if opts.EUI64 {
+		n = 8
+	}
+	b := make(net.HardwareAddr, n)
+	rng := nw.r.source()
+	fillRandomBytes(b, rng)
+
+	prefix, bits := opts.Prefix, opts.PrefixBits
+	if len(prefix) == 0 && opts.Vendor != "" {
+		var matches []*ouiEntry
+		for i := range ouiRegistry {
+			if containsFold(ouiRegistry[i].vendor, opts.Vendor) {
+				matches = append(matches, &ouiRegistry[i])
+			}
+		}
+		if len(matches) == 0 {
+			return nil, ErrUnknownVendor
+		}
+		prefix, bits = matches[uniformUint64n(uint64(len(matches)), rng)].prefix[:], MALBits
+	}
+	if len(prefix) == 0 {
+		setMACFlags(b, opts.Local, opts.Multicast)
+		return b, nil
+	}
+	if bits == 0 {
+		bits = 8 * len(prefix)
+	}
+	if bits < 0 || bits > 8*len(prefix) || bits > 8*n {
+		return nil, ErrInvalidMACPrefix
+	}
+	full := bits / 8
+	copy(b, prefix[:full])
+	if rem := bits % 8; rem != 0 {
+		mask := byte(0xFF) << (8 - rem)
+		b[full] = prefix[full]&mask | b[full]&^mask
+	}
+	return b, nil
+}
+
+func setMACFlags(b []byte, local, multicast bool) {
+	if local {
+		b[0] |= 0x02
+	} else {
+		b[0] &^= 0x02
+	}
+	if multicast {
+		b[0] |= 0x01
+	} else {
+		b[0] &^= 0x01
+	}
+}
+
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+// MACVendor returns the vendor of mac's OUI according to the embedded
+// registry snapshot.
+func (network) MACVendor(mac net.HardwareAddr) (string, bool) {
+	if len(mac) < 3 {
+		return "", false
+	}
+	for _, e := range ouiRegistry {
+		if e.prefix == [3]byte(mac[:3]) {
+			return e.vendor, true
+		}
+	}
+	return "", false
+}
+
+// FormatMAC formats mac in the specified notation, using uppercase
+// hexadecimal digits if uppercase is true.
+func (network) FormatMAC(mac net.HardwareAddr, format MACFormat, uppercase bool) string {
+	dict := lhexdict
+	if uppercase {
+		dict = uhexdict
+	}
+	out := make([]byte, 0, 3*len(mac))
+	for i, c := range mac {
+		switch {
+		case i == 0:
+		case format == ColonFormat:
+			out = append(out, ':')
+		case format == DashFormat:
+			out = append(out, '-')
+		case format == DotFormat && i%2 == 0:
+			out = append(out, '.')
+		}
+		out = append(out, dict[c>>4], dict[c&0x0F])
+	}
+	return string(out)
+}
@@ -0,0 +1,91 @@
+package randomizer_test
+
+import (
+	"bytes"
+	"net"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/colduction/randomizer"
+)
+
+func TestNetworkMACPrefix(t *testing.T) {
+	oui := []byte{0x00, 0x1B, 0x63}
+	for range 1000 {
+		mac, err := randomizer.Network.MAC(&randomizer.MACOptions{Prefix: oui})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(mac) != 6 || !bytes.Equal(mac[:3], oui) {
+			t.Fatalf("MAC with OUI returned %v", mac)
+		}
+	}
+
+	maS := []byte{0x70, 0xB3, 0xD5, 0x4C, 0x50}
+	for range 1000 {
+		mac, err := randomizer.Network.MAC(&randomizer.MACOptions{Prefix: maS, PrefixBits: randomizer.MASBits, EUI64: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(mac) != 8 || !bytes.Equal(mac[:4], maS[:4]) || mac[4]&0xF0 != 0x50 {
+			t.Fatalf("EUI-64 with MA-S prefix returned %v", mac)
+		}
+	}
+
+	if _, err := randomizer.Network.MAC(&randomizer.MACOptions{Prefix: oui, PrefixBits: 28}); err != randomizer.ErrInvalidMACPrefix {
+		t.Fatalf("MAC with PrefixBits beyond Prefix error = %v, want ErrInvalidMACPrefix", err)
+	}
+}
+
+func TestNetworkMACVendor(t *testing.T) {
+	for range 1000 {
+		mac, err := randomizer.Network.MAC(&randomizer.MACOptions{Vendor: "vmware"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		vendor, ok := randomizer.Network.MACVendor(mac)
+		if !ok || !strings.Contains(vendor, "VMware") {
+			t.Fatalf("MAC with vendor VMware returned %v (vendor %q)", mac, vendor)
+		}
+	}
+	if _, err := randomizer.Network.MAC(&randomizer.MACOptions{Vendor: "no such vendor"}); err != randomizer.ErrUnknownVendor {
+		t.Fatalf("MAC with unknown vendor error = %v, want ErrUnknownVendor", err)
+	}
+
+	mac, err := randomizer.Network.MAC(&randomizer.MACOptions{Local: true, Multicast: true})
+	if err != nil || mac[0]&0x03 != 0x03 {
+		t.Fatalf("MAC with Local and Multicast returned %v, %v", mac, err)
+	}
+}
+
+func TestNetworkFormatMAC(t *testing.T) {
+	mac := net.HardwareAddr{0x00, 0x1B, 0x63, 0x84, 0x45, 0xE6}
+	cases := []struct {
+		format    randomizer.MACFormat
+		uppercase bool
+		want      string
+	}{
+		{randomizer.ColonFormat, false, "00:1b:63:84:45:e6"},
+		{randomizer.DashFormat, true, "00-1B-63-84-45-E6"},
+		{randomizer.DotFormat, false, "001b.6384.45e6"},
+		{randomizer.BareFormat, true, "001B638445E6"},
+	}
+	for _, tc := range cases {
+		if got := randomizer.Network.FormatMAC(mac, tc.format, tc.uppercase); got != tc.want {
+			t.Fatalf("FormatMAC(%d, %t) = %q, want %q", tc.format, tc.uppercase, got, tc.want)
+		}
+	}
+	eui := append(mac[:3:3], 0xFF, 0xFE, 0x84, 0x45, 0xE6)
+	if got := randomizer.Network.FormatMAC(eui, randomizer.DotFormat, false); !regexp.MustCompile(`^([0-9a-f]{4}\.){3}[0-9a-f]{4}$`).MatchString(got) {
+		t.Fatalf("FormatMAC EUI-64 dot notation = %q", got)
+	}
+}
+
+func BenchmarkNetworkMACVendor(b *testing.B) {
+	opts := &randomizer.MACOptions{Vendor: "Apple"}
+	b.ReportAllocs()
+	for b.Loop() {
+		benchMAC, _ = randomizer.Network.MAC(opts)
+	}
+}
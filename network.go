@@ -1,8 +1,13 @@
 package randomizer
 
-import "net"
+import (
+	"net"
+	"net/netip"
+)
 
-type network struct{}
+type network struct {
+	r *Rand // nil draws from DefaultHashPool
+}
 
 var Network network
 
@@ -51,31 +56,140 @@ const (
 	GlobalScope         MulticastScope = 0xE
 )
 
+// Addr4 generates a random IPv4 address without heap allocation.
+func (n network) Addr4() netip.Addr {
+	var b [net.IPv4len]byte
+	rng := n.r.source()
+	fillRandomBytes(b[:], rng)
+	return netip.AddrFrom4(b)
+}
+
+// Addr6 generates a random IPv6 address without heap allocation.
+func (n network) Addr6() netip.Addr {
+	var b [net.IPv6len]byte
+	rng := n.r.source()
+	fillRandomBytes(b[:], rng)
+	return netip.AddrFrom16(b)
+}
+
+// setUnicastPrefix overwrites the leading bits of b with the prefix of unicastType.
+func setUnicastPrefix(b *[net.IPv6len]byte, unicastType UnicastType) {
+	switch unicastType {
+	case GlobalType:
+		b[0] = (b[0] & 0x1F) | 0x20
+	case LinkLocalType:
+		b[0] = 0xFE
+		b[1] = (b[1] & 0x3F) | 0x80
+	case SiteLocalType:
+		b[0] = 0xFE
+		b[1] = (b[1] & 0x3F) | 0xC0
+	case UniqueLocalType:
+		b[0] = 0xFD
+	}
+}
+
+// Addr6Unicast generates a random IPv6 unicast address of the specified
+// unicast type without heap allocation.
+func (n network) Addr6Unicast(unicastType UnicastType) netip.Addr {
+	var b [net.IPv6len]byte
+	rng := n.r.source()
+	fillRandomBytes(b[:], rng)
+	setUnicastPrefix(&b, unicastType)
+	return netip.AddrFrom16(b)
+}
+
+// ipv6UnicastPrefixes are the address blocks of each UnicastType.
+var ipv6UnicastPrefixes = [...]netip.Prefix{
+	GlobalType:      netip.MustParsePrefix("2000::/3"),
+	LinkLocalType:   netip.MustParsePrefix("fe80::/10"),
+	SiteLocalType:   netip.MustParsePrefix("fec0::/10"),
+	UniqueLocalType: netip.MustParsePrefix("fc00::/7"),
+}
+
+// Addr6UnicastIn generates a random IPv6 unicast address of the specified
+// type, chosen uniformly among the addresses not removed by opts. For example,
+// ExcludeSpecialPurpose keeps GlobalType addresses out of documentation,
+// Teredo, 6to4 and other registry blocks. Unique-local addresses are drawn
+// from fd00::/8 as in Addr6Unicast, and an unknown type draws from ::/0.
+func (n network) Addr6UnicastIn(unicastType UnicastType, opts *PrefixOptions) (netip.Addr, error) {
+	p := netip.PrefixFrom(netip.IPv6Unspecified(), 0)
+	switch unicastType {
+	case UniqueLocalType:
+		p = netip.PrefixFrom(netip.AddrFrom16([16]byte{0xFD}), 8)
+	case GlobalType, LinkLocalType, SiteLocalType:
+		p = ipv6UnicastPrefixes[unicastType]
+	}
+	rng := n.r.source()
+	return addrInPrefix(p, opts, rng)
+}
+
+// Addr6Multicast generates a random IPv6 multicast address with the specified
+// scope and no flags set, without heap allocation.
+func (n network) Addr6Multicast(scope MulticastScope) netip.Addr {
+	var b [net.IPv6len]byte
+	rng := n.r.source()
+	fillRandomBytes(b[:], rng)
+	b[0] = 0xFF
+	b[1] = uint8(scope) & 0x0F
+	return netip.AddrFrom16(b)
+}
+
+// randomPort returns a port in [1, 65535]; port 0 is reserved as a wildcard.
+func randomPort(rng *wordRNG) uint16 {
+	return uint16(1 + uniformUint64n(0xFFFF, rng))
+}
+
+// AddrPort4 generates a random IPv4 socket address with a port in [1, 65535].
+func (n network) AddrPort4() netip.AddrPort {
+	var b [net.IPv4len]byte
+	rng := n.r.source()
+	fillRandomBytes(b[:], rng)
+	return netip.AddrPortFrom(netip.AddrFrom4(b), randomPort(rng))
+}
+
+// AddrPort6 generates a random IPv6 socket address with a port in [1, 65535].
+func (n network) AddrPort6() netip.AddrPort {
+	var b [net.IPv6len]byte
+	rng := n.r.source()
+	fillRandomBytes(b[:], rng)
+	return netip.AddrPortFrom(netip.AddrFrom16(b), randomPort(rng))
+}
+
+// Prefix4 generates a random IPv4 prefix of the specified length with its host
+// bits cleared. bits is clamped to [0, 32].
+func (n network) Prefix4(bits int) netip.Prefix {
+	bits = min(max(bits, 0), 8*net.IPv4len)
+	p, _ := n.Addr4().Prefix(bits)
+	return p
+}
+
+// Prefix6 generates a random IPv6 prefix of the specified length with its host
+// bits cleared. bits is clamped to [0, 128].
+func (n network) Prefix6(bits int) netip.Prefix {
+	bits = min(max(bits, 0), 8*net.IPv6len)
+	p, _ := n.Addr6().Prefix(bits)
+	return p
+}
+
 // IPv4Addr generates a random IPv4 address by creating a 4-byte IP
 // using a hash-based approach for randomness, ensuring a unique address.
-func (network) IPv4Addr() net.IP {
-	b := make(net.IP, net.IPv4len)
-	rng := newWordRNG()
-	fillRandomBytes(b, &rng)
-	return b
+func (n network) IPv4Addr() net.IP {
+	return net.IP(n.Addr4().AsSlice())
 }
 
 // IPv6Addr generates a random IPv6 address by creating a 16-byte IP
 // through a hash-based approach, ensuring a unique 128-bit address.
-func (network) IPv6Addr() net.IP {
-	b := make(net.IP, net.IPv6len)
-	rng := newWordRNG()
-	fillRandomBytes(b, &rng)
-	return b
+func (n network) IPv6Addr() net.IP {
+	return net.IP(n.Addr6().AsSlice())
 }
 
 // MACAddr generates a random MAC address with configurable local and multicast
 // bits. The U/L bit controls whether the address is locally administered, and the
 // I/G bit controls whether the address is intended for multicast traffic.
-func (network) MACAddr(local, multicast bool) net.HardwareAddr {
+func (n network) MACAddr(local, multicast bool) net.HardwareAddr {
 	b := make(net.HardwareAddr, 6)
-	rng := newWordRNG()
-	fillRandomBytes(b, &rng)
+	rng := n.r.source()
+	fillRandomBytes(b, rng)
 	// Set the U/L bit in the first byte
 	if local {
 		b[0] = b[0] | 0x02
@@ -93,32 +207,12 @@ func (network) MACAddr(local, multicast bool) net.HardwareAddr {
 
 // IPv6UnicastAddr generates a random IPv6 unicast address of a specified
 // unicast type by configuring address prefixes.
-func (network) IPv6UnicastAddr(unicastType UnicastType) net.IP {
-	b := make(net.IP, net.IPv6len)
-	rng := newWordRNG()
-	fillRandomBytes(b, &rng)
-	switch unicastType {
-	case GlobalType:
-		b[0] = (b[0] & 0x1F) | 0x20
-	case LinkLocalType:
-		b[0] = 0xFE
-		b[1] = (b[1] & 0x3F) | 0x80
-	case SiteLocalType:
-		b[0] = 0xFE
-		b[1] = (b[1] & 0x3F) | 0xC0
-	case UniqueLocalType:
-		b[0] = 0xFD
-	}
-	return b
+func (n network) IPv6UnicastAddr(unicastType UnicastType) net.IP {
+	return net.IP(n.Addr6Unicast(unicastType).AsSlice())
 }
 
 // IPv6MulticastAddr generates a random IPv6 multicast address with a
 // specified multicast scope, setting the appropriate prefix and scope bits.
-func (network) IPv6MulticastAddr(scope MulticastScope) net.IP {
-	b := make(net.IP, net.IPv6len)
-	rng := newWordRNG()
-	fillRandomBytes(b, &rng)
-	b[0] = 0xFF
-	b[1] = uint8(scope) & 0x0F
-	return b
+func (n network) IPv6MulticastAddr(scope MulticastScope) net.IP {
+	return net.IP(n.Addr6Multicast(scope).AsSlice())
 }
@@ -2,14 +2,17 @@ package randomizer_test
 
 import (
 	"net"
+	"net/netip"
 	"testing"
 
 	"github.com/colduction/randomizer"
 )
 
 var (
-	benchIP  net.IP
-	benchMAC net.HardwareAddr
+	benchIP       net.IP
+	benchMAC      net.HardwareAddr
+	benchAddr     netip.Addr
+	benchAddrPort netip.AddrPort
 )
 
 func TestNetworkIPv4Addr(t *testing.T) {
@@ -114,6 +117,59 @@ func TestNetworkIPv6MulticastScope(t *testing.T) {
 	}
 }
 
+func TestNetworkNetipAddrs(t *testing.T) {
+	if a := randomizer.Network.Addr4(); !a.Is4() {
+		t.Fatalf("Addr4 returned %v, want IPv4", a)
+	}
+	if a := randomizer.Network.Addr6(); !a.Is6() {
+		t.Fatalf("Addr6 returned %v, want IPv6", a)
+	}
+	if a := randomizer.Network.Addr6Unicast(randomizer.LinkLocalType); !a.IsLinkLocalUnicast() {
+		t.Fatalf("Addr6Unicast(LinkLocalType) returned %v, want link-local", a)
+	}
+	if a := randomizer.Network.Addr6Multicast(randomizer.LinkLocalScope); !a.IsLinkLocalMulticast() {
+		t.Fatalf("Addr6Multicast(LinkLocalScope) returned %v, want link-local multicast", a)
+	}
+	for range 1000 {
+		if ap := randomizer.Network.AddrPort4(); !ap.Addr().Is4() || ap.Port() == 0 {
+			t.Fatalf("AddrPort4 returned %v", ap)
+		}
+		if ap := randomizer.Network.AddrPort6(); !ap.Addr().Is6() || ap.Port() == 0 {
+			t.Fatalf("AddrPort6 returned %v", ap)
+		}
+	}
+}
+
+func TestNetworkPrefixes(t *testing.T) {
+	for _, bits := range []int{-1, 0, 8, 24, 32, 33} {
+		p := randomizer.Network.Prefix4(bits)
+		want := min(max(bits, 0), 32)
+		if !p.IsValid() || !p.Addr().Is4() || p.Bits() != want || p.Masked() != p {
+			t.Fatalf("Prefix4(%d) = %v", bits, p)
+		}
+	}
+	for _, bits := range []int{0, 48, 64, 128, 129} {
+		p := randomizer.Network.Prefix6(bits)
+		want := min(bits, 128)
+		if !p.IsValid() || !p.Addr().Is6() || p.Bits() != want || p.Masked() != p {
+			t.Fatalf("Prefix6(%d) = %v", bits, p)
+		}
+	}
+}
+
+func TestNetworkNetipZeroAllocs(t *testing.T) {
+	allocs := testing.AllocsPerRun(100, func() {
+		benchAddr = randomizer.Network.Addr4()
+		benchAddr = randomizer.Network.Addr6()
+		benchAddr = randomizer.Network.Addr6Unicast(randomizer.GlobalType)
+		benchAddr = randomizer.Network.Addr6Multicast(randomizer.GlobalScope)
+		benchAddrPort = randomizer.Network.AddrPort6()
+	})
+	if allocs != 0 {
+		t.Fatalf("netip generators allocated %v times per run, want 0", allocs)
+	}
+}
+
 func BenchmarkNetworkIPv4Addr(b *testing.B) {
 	b.ReportAllocs()
 	for b.Loop() {
@@ -148,3 +204,10 @@ func BenchmarkNetworkIPv6MulticastAddr(b *testing.B) {
 		benchIP = randomizer.Network.IPv6MulticastAddr(randomizer.GlobalScope)
 	}
 }
+
+func BenchmarkNetworkAddr6(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		benchAddr = randomizer.Network.Addr6()
+	}
+}
@@ -37,18 +37,21 @@ func IntInterval[T SignedIntegers](min, max T) T {
 	if min == max {
 		return min
 	}
+	rng := newWordRNG()
+	return intInterval(min, max, &rng)
+}
+
+func intInterval[T SignedIntegers](min, max T, rng *wordRNG) T {
 	if min > max {
 		min, max = max, min
 	}
-	rng := newWordRNG()
-
 	// Map signed values to a monotonic unsigned domain.
 	const signMask = uint64(1) << 63
 	minU := uint64(int64(min)) ^ signMask
 	maxU := uint64(int64(max)) ^ signMask
 	span := maxU - minU
 
-	v := uniformUint64n(span, &rng)
+	v := uniformUint64n(span, rng)
 	return T(int64((minU + v) ^ signMask))
 }
 
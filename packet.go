@@ -0,0 +1,298 @@
+package randomizer
+
+import (
+	"encoding/binary"
+	"errors"
+	"net"
+	"net/netip"
+)
+
+var (
+	// ErrAddrFamilyMismatch is returned when the addresses or protocol of a
+	// packet, or the addresses of an exported flow, mix IPv4 and IPv6.
+	ErrAddrFamilyMismatch = errors.New("randomizer: mismatched address families")
+	// ErrPacketTooLarge is returned when a payload does not fit in an IP packet.
+	ErrPacketTooLarge = errors.New("randomizer: packet exceeds maximum IP length")
+	// ErrInvalidPacketMAC is returned when a packet MAC address is set but is
+	// not a 6-byte EUI-48.
+	ErrInvalidPacketMAC = errors.New("randomizer: packet MAC address is not EUI-48")
+)
+
+// IPProtocol is an IANA assigned internet protocol number.
+// ref: https://www.iana.org/assignments/protocol-numbers
+type IPProtocol uint8
+
+const (
+	ProtoICMP   IPProtocol = 1
+	ProtoTCP    IPProtocol = 6
+	ProtoUDP    IPProtocol = 17
+	ProtoICMPv6 IPProtocol = 58
+)
+
+// TCP header flags.
+// ref: https://datatracker.ietf.org/doc/html/rfc9293#section-3.1
+const (
+	TCPFin uint8 = 1 << iota
+	TCPSyn
+	TCPRst
+	TCPPsh
+	TCPAck
+	TCPUrg
+)
+
+// tcpFlagSets are the flag combinations of an ordinary connection.
+var tcpFlagSets = [...]uint8{TCPSyn, TCPSyn | TCPAck, TCPAck, TCPPsh | TCPAck, TCPFin | TCPAck}
+
+// initialTTLs are the default initial TTLs of common operating systems.
+var initialTTLs = [...]uint8{64, 128, 255}
+
+// Header sizes and EtherTypes used by Network.Packet.
+const (
+	ethernetHeaderLen = 14
+	ipv4HeaderLen     = 20
+	ipv6HeaderLen     = 40
+	udpHeaderLen      = 8
+	tcpHeaderLen      = 20
+	icmpHeaderLen     = 8
+	maxIPLen          = 0xFFFF
+
+	etherTypeIPv4 = 0x0800
+	etherTypeIPv6 = 0x86DD
+)
+
+// PacketOptions configures Network.Packet. Zero fields are randomized.
+type PacketOptions struct {
+	// Protocol is ProtoUDP, ProtoTCP, ProtoICMP or ProtoICMPv6. Zero picks
+	// UDP, TCP or ICMP; ProtoICMP over IPv6 is sent as ICMPv6.
+	Protocol IPProtocol
+	// IPv6 generates IPv6 addresses when neither Src nor Dst is set.
+	IPv6 bool
+	// Src and Dst are the IP addresses. An unset address is generated in the
+	// family of the other one: a public IPv4 address or a global IPv6 address.
+	Src, Dst netip.Addr
+	// SrcMAC and DstMAC are the 6-byte Ethernet addresses; nil generates a
+	// universally administered unicast address.
+	SrcMAC, DstMAC net.HardwareAddr
+	// SrcPort and DstPort are the UDP or TCP ports. Zero picks a dynamic
+	// source port and any destination port.
+	SrcPort, DstPort uint16
+	// TTL is the IPv4 time to live or IPv6 hop limit. Zero picks a common
+	// initial TTL decreased by up to 31 hops.
+	TTL uint8
+	// TCPFlags sets the TCP flags. Zero picks a combination seen in ordinary
+	// connections; the acknowledgment number is zero unless TCPAck is set.
+	TCPFlags uint8
+	// Payload is the transport payload. If nil, a random payload of up to
+	// MaxPayload bytes is generated.
+	Payload []byte
+	// MaxPayload bounds the random payload length; zero means 64.
+	MaxPayload int
+}
+
+// Packet generates an Ethernet II frame carrying an IPv4 or IPv6 packet with
+// a UDP, TCP or ICMP echo request. Fields not set in opts are random but
+// consistent: lengths and the IPv4, UDP, TCP and ICMP checksums are valid,
+// and ports, sequence numbers and identifiers are drawn at random. A nil opts
+// randomizes everything.
+func (n network) Packet(opts *PacketOptions) ([]byte, error) {
+	if opts == nil {
+		opts = new(PacketOptions)
+	}
+	if (opts.SrcMAC != nil && len(opts.SrcMAC) != 6) || (opts.DstMAC != nil && len(opts.DstMAC) != 6) {
+		return nil, ErrInvalidPacketMAC
+	}
+	rng := n.r.source()
+
+	src, dst := opts.Src.Unmap(), opts.Dst.Unmap()
+	switch {
+	case src.IsValid() && dst.IsValid():
+		if src.Is4() != dst.Is4() {
+			return nil, ErrAddrFamilyMismatch
+		}
+	case src.IsValid():
+		dst = packetAddr(src.Is6(), rng)
+	case dst.IsValid():
+		src = packetAddr(dst.Is6(), rng)
+	default:
+		src, dst = packetAddr(opts.IPv6, rng), packetAddr(opts.IPv6, rng)
+	}
+	is6 := src.Is6()
+
+	proto := opts.Protocol
+	if proto == 0 {
+		proto = [...]IPProtocol{ProtoUDP, ProtoTCP, ProtoICMP}[uniformUint64n(3, rng)]
+	}
+	switch {
+	case proto == ProtoICMP && is6:
+		proto = ProtoICMPv6
+	case proto == ProtoICMPv6 && !is6:
+		return nil, ErrAddrFamilyMismatch
+	}
+
+	payload := opts.Payload
+	if payload == nil {
+		maxPayload := opts.MaxPayload
+		if maxPayload <= 0 {
+			maxPayload = 64
+		}
+		payload = make([]byte, uniformUint64n(uint64(maxPayload)+1, rng))
+		fillRandomBytes(payload, rng)
+	}
+
+	l4Len := len(payload)
+	switch proto {
+	case ProtoTCP:
+		l4Len += tcpHeaderLen
+	case ProtoUDP:
+		l4Len += udpHeaderLen
+	default:
+		l4Len += icmpHeaderLen
+	}
+	// The IPv4 total length includes the header; the IPv6 payload length
+	// does not.
+	ipLen, lengthField := ipv4HeaderLen+l4Len, ipv4HeaderLen+l4Len
+	if is6 {
+		ipLen, lengthField = ipv6HeaderLen+l4Len, l4Len
+	}
+	if lengthField > maxIPLen {
+		return nil, ErrPacketTooLarge
+	}
+
+	out := make([]byte, 0, ethernetHeaderLen+ipLen)
+	out = appendPacketMAC(out, opts.DstMAC, rng)
+	out = appendPacketMAC(out, opts.SrcMAC, rng)
+
+	ttl := opts.TTL
+	if ttl == 0 {
+		ttl = initialTTLs[uniformUint64n(uint64(len(initialTTLs)), rng)] - uint8(uniformUint64n(32, rng))
+	}
+	if is6 {
+		out = binary.BigEndian.AppendUint16(out, etherTypeIPv6)
+		// Version 6, traffic class 0 and a random 20-bit flow label.
+		out = binary.BigEndian.AppendUint32(out, 6<<28|uint32(rng.next64())&0xFFFFF)
+		out = binary.BigEndian.AppendUint16(out, uint16(l4Len))
+		out = append(out, byte(proto), ttl)
+		out = append(out, src.AsSlice()...)
+		out = append(out, dst.AsSlice()...)
+	} else {
+		out = binary.BigEndian.AppendUint16(out, etherTypeIPv4)
+		ip := len(out)
+		out = append(out, 0x45, 0)
+		out = binary.BigEndian.AppendUint16(out, uint16(ipLen))
+		out = binary.BigEndian.AppendUint16(out, uint16(rng.next64()))
+		out = append(out, 0x40, 0) // don't fragment
+		out = append(out, ttl, byte(proto), 0, 0)
+		out = append(out, src.AsSlice()...)
+		out = append(out, dst.AsSlice()...)
+		binary.BigEndian.PutUint16(out[ip+10:], checksum(out[ip:], 0))
+	}
+
+	l4 := len(out)
+	switch proto {
+	case ProtoTCP, ProtoUDP:
+		srcPort, dstPort := opts.SrcPort, opts.DstPort
+		if srcPort == 0 {
+			srcPort, _ = pickPort(&PortOptions{Range: DynamicPorts}, rng)
+		}
+		if dstPort == 0 {
+			dstPort = randomPort(rng)
+		}
+		out = binary.BigEndian.AppendUint16(out, srcPort)
+		out = binary.BigEndian.AppendUint16(out, dstPort)
+		if proto == ProtoUDP {
+			out = binary.BigEndian.AppendUint16(out, uint16(l4Len))
+			out = append(out, 0, 0)
+			break
+		}
+		flags := opts.TCPFlags
+		if flags == 0 {
+			flags = tcpFlagSets[uniformUint64n(uint64(len(tcpFlagSets)), rng)]
+		}
+		seq := rng.next64()
+		ack := uint32(seq >> 32)
+		if flags&TCPAck == 0 {
+			ack = 0
+		}
+		out = binary.BigEndian.AppendUint32(out, uint32(seq))
+		out = binary.BigEndian.AppendUint32(out, ack)
+		out = append(out, tcpHeaderLen/4<<4, flags)
+		out = binary.BigEndian.AppendUint16(out, uint16(1024+uniformUint64n(0xFFFF-1024+1, rng)))
+		out = append(out, 0, 0, 0, 0) // checksum and urgent pointer
+	default:
+		typ := byte(8) // echo request
+		if proto == ProtoICMPv6 {
+			typ = 128
+		}
+		out = append(out, typ, 0, 0, 0)
+		out = binary.BigEndian.AppendUint32(out, uint32(rng.next64()))
+	}
+	out = append(out, payload...)
+
+	// The ICMPv4 checksum covers the message only; the others include the
+	// pseudo-header.
+	// ref: https://datatracker.ietf.org/doc/html/rfc8200#section-8.1
+	var sum uint32
+	if proto != ProtoICMP {
+		sum = pseudoHeaderSum(src, dst, proto, l4Len)
+	}
+	csum := checksum(out[l4:], sum)
+	offset := 2
+	switch proto {
+	case ProtoTCP:
+		offset = 16
+	case ProtoUDP:
+		offset = 6
+		if csum == 0 {
+			csum = 0xFFFF // zero means no checksum in UDP
+		}
+	}
+	binary.BigEndian.PutUint16(out[l4+offset:], csum)
+	return out, nil
+}
+
+func packetAddr(is6 bool, rng *wordRNG) netip.Addr {
+	if is6 {
+		a, _ := addrInPrefix(ipv6UnicastPrefixes[GlobalType], nil, rng)
+		return a
+	}
+	return ipv4Categories[IPv4Public].pick(rng)
+}
+
+func appendPacketMAC(out []byte, mac net.HardwareAddr, rng *wordRNG) []byte {
+	if mac != nil {
+		return append(out, mac...)
+	}
+	var b [6]byte
+	fillRandomBytes(b[:], rng)
+	setMACFlags(b[:], false, false)
+	return append(out, b[:]...)
+}
+
+func pseudoHeaderSum(src, dst netip.Addr, proto IPProtocol, length int) uint32 {
+	sum := sumWords(src.AsSlice(), 0)
+	sum = sumWords(dst.AsSlice(), sum)
+	return sum + uint32(proto) + uint32(length)&0xFFFF + uint32(length)>>16
+}
+
+// sumWords adds b to sum as big-endian 16-bit words, padding an odd length
+// with a zero byte.
+func sumWords(b []byte, sum uint32) uint32 {
+	for len(b) >= 2 {
+		sum += uint32(b[0])<<8 | uint32(b[1])
+		b = b[2:]
+	}
+	if len(b) == 1 {
+		sum += uint32(b[0]) << 8
+	}
+	return sum
+}
+
+// checksum returns the internet checksum of b added to the partial sum.
+// ref: https://datatracker.ietf.org/doc/html/rfc1071
+func checksum(b []byte, sum uint32) uint16 {
+	sum = sumWords(b, sum)
+	for sum > 0xFFFF {
+		sum = sum>>16 + sum&0xFFFF
+	}
+	return ^uint16(sum)
+}
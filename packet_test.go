@@ -0,0 +1,178 @@
+package randomizer_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"net"
+	"net/netip"
+	"testing"
+
+	"github.com/colduction/randomizer"
+)
+
+// onesSum returns the folded one's complement sum of b plus sum; a packet with
+// a valid checksum sums to 0xFFFF.
+func onesSum(b []byte, sum uint32) uint16 {
+	for i := 0; i+1 < len(b); i += 2 {
+		sum += uint32(b[i])<<8 | uint32(b[i+1])
+	}
+	if len(b)%2 == 1 {
+		sum += uint32(b[len(b)-1]) << 8
+	}
+	for sum > 0xFFFF {
+		sum = sum>>16 + sum&0xFFFF
+	}
+	return uint16(sum)
+}
+
+func pseudoSum(src, dst []byte, proto byte, length int) uint32 {
+	return uint32(onesSum(append(append([]byte{}, src...), dst...), uint32(proto)+uint32(length)))
+}
+
+// checkFrame validates the lengths and checksums of a frame built by
+// Network.Packet and returns its transport protocol.
+func checkFrame(t *testing.T, f []byte) randomizer.IPProtocol {
+	t.Helper()
+	be := binary.BigEndian
+	if f[0]&0x01 != 0 || f[6]&0x01 != 0 {
+		t.Fatalf("multicast MAC in %x", f[:12])
+	}
+	var proto byte
+	var l4, src, dst []byte
+	switch be.Uint16(f[12:]) {
+	case 0x0800:
+		ip := f[14:]
+		if ip[0] != 0x45 || int(be.Uint16(ip[2:])) != len(ip) {
+			t.Fatalf("bad IPv4 header %x", ip[:20])
+		}
+		if onesSum(ip[:20], 0) != 0xFFFF {
+			t.Fatalf("bad IPv4 header checksum %x", ip[:20])
+		}
+		if ip[8] == 0 {
+			t.Fatal("zero TTL")
+		}
+		proto, src, dst, l4 = ip[9], ip[12:16], ip[16:20], ip[20:]
+	case 0x86DD:
+		ip := f[14:]
+		if ip[0]>>4 != 6 || int(be.Uint16(ip[4:])) != len(ip)-40 {
+			t.Fatalf("bad IPv6 header %x", ip[:40])
+		}
+		proto, src, dst, l4 = ip[6], ip[8:24], ip[24:40], ip[40:]
+	default:
+		t.Fatalf("unexpected EtherType in %x", f[:14])
+	}
+	sum := pseudoSum(src, dst, proto, len(l4))
+	switch randomizer.IPProtocol(proto) {
+	case randomizer.ProtoUDP:
+		if int(be.Uint16(l4[4:])) != len(l4) {
+			t.Fatalf("bad UDP length %x", l4[:8])
+		}
+	case randomizer.ProtoTCP:
+		if l4[12] != 5<<4 {
+			t.Fatalf("bad TCP data offset %x", l4[:20])
+		}
+		if l4[13]&randomizer.TCPAck == 0 && be.Uint32(l4[8:]) != 0 {
+			t.Fatalf("acknowledgment number without ACK %x", l4[:20])
+		}
+	case randomizer.ProtoICMP:
+		sum = 0
+		if l4[0] != 8 {
+			t.Fatalf("ICMP type %d", l4[0])
+		}
+	case randomizer.ProtoICMPv6:
+		if l4[0] != 128 {
+			t.Fatalf("ICMPv6 type %d", l4[0])
+		}
+	default:
+		t.Fatalf("unexpected protocol %d", proto)
+	}
+	if onesSum(l4, sum) != 0xFFFF {
+		t.Fatalf("bad protocol %d checksum in %x", proto, f)
+	}
+	return randomizer.IPProtocol(proto)
+}
+
+func TestNetworkPacket(t *testing.T) {
+	seen := map[randomizer.IPProtocol]bool{}
+	for i := range 3000 {
+		f, err := randomizer.Network.Packet(&randomizer.PacketOptions{IPv6: i%2 == 1, MaxPayload: 101})
+		if err != nil {
+			t.Fatal(err)
+		}
+		seen[checkFrame(t, f)] = true
+	}
+	if len(seen) != 4 {
+		t.Fatalf("protocols seen: %v", seen)
+	}
+}
+
+func TestNetworkPacketOptions(t *testing.T) {
+	src := netip.MustParseAddr("192.0.2.1")
+	payload := []byte("hello")
+	f, err := randomizer.Network.Packet(&randomizer.PacketOptions{
+		Protocol: randomizer.ProtoTCP,
+		Src:      src,
+		DstPort:  443,
+		TTL:      7,
+		TCPFlags: randomizer.TCPSyn,
+		Payload:  payload,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkFrame(t, f)
+	ip := f[14:]
+	if ip[8] != 7 || !bytes.Equal(ip[12:16], src.AsSlice()) {
+		t.Fatalf("unexpected IPv4 header %x", ip[:20])
+	}
+	tcp := ip[20:]
+	if sp := binary.BigEndian.Uint16(tcp); sp < 49152 {
+		t.Fatalf("source port %d is not dynamic", sp)
+	}
+	if binary.BigEndian.Uint16(tcp[2:]) != 443 || tcp[13] != randomizer.TCPSyn || !bytes.Equal(tcp[20:], payload) {
+		t.Fatalf("unexpected TCP segment %x", tcp)
+	}
+
+	f, err = randomizer.Network.Packet(&randomizer.PacketOptions{
+		Protocol: randomizer.ProtoICMP,
+		Dst:      netip.MustParseAddr("2001:db8::1"),
+		Payload:  []byte{},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if checkFrame(t, f) != randomizer.ProtoICMPv6 || len(f) != 14+40+8 {
+		t.Fatalf("unexpected ICMPv6 frame %x", f)
+	}
+}
+
+func TestNetworkPacketErrors(t *testing.T) {
+	tests := []struct {
+		opts randomizer.PacketOptions
+		err  error
+	}{
+		{randomizer.PacketOptions{Src: netip.MustParseAddr("192.0.2.1"), Dst: netip.MustParseAddr("2001:db8::1")}, randomizer.ErrAddrFamilyMismatch},
+		{randomizer.PacketOptions{Protocol: randomizer.ProtoICMPv6}, randomizer.ErrAddrFamilyMismatch},
+		{randomizer.PacketOptions{SrcMAC: net.HardwareAddr{1, 2, 3, 4, 5, 6, 7, 8}}, randomizer.ErrInvalidPacketMAC},
+		{randomizer.PacketOptions{DstMAC: net.HardwareAddr{}}, randomizer.ErrInvalidPacketMAC},
+		{randomizer.PacketOptions{Protocol: randomizer.ProtoUDP, Payload: make([]byte, 65535-20-8+1)}, randomizer.ErrPacketTooLarge},
+	}
+	for _, tt := range tests {
+		if _, err := randomizer.Network.Packet(&tt.opts); !errors.Is(err, tt.err) {
+			t.Errorf("Packet(%+v) error = %v, want %v", tt.opts, err, tt.err)
+		}
+	}
+	f, err := randomizer.Network.Packet(&randomizer.PacketOptions{Protocol: randomizer.ProtoUDP, Payload: make([]byte, 65535-20-8)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkFrame(t, f)
+}
+
+func BenchmarkNetworkPacket(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		benchWordBytes, _ = randomizer.Network.Packet(nil)
+	}
+}
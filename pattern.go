@@ -0,0 +1,110 @@
+package randomizer
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+// patternEscape makes the following mask byte a literal.
+const patternEscape byte = '\\'
+
+var (
+	// ErrInvalidPlaceholder is returned when registering the escape character
+	// or a non-ASCII byte as a placeholder, or an alphabet that is not ASCII.
+	ErrInvalidPlaceholder = errors.New("randomizer: invalid pattern placeholder")
+	// ErrEmptyAlphabet is returned when registering a placeholder with an empty alphabet.
+	ErrEmptyAlphabet = errors.New("randomizer: empty placeholder alphabet")
+)
+
+// patternTable maps mask bytes to alphabets; an empty entry is a literal.
+type patternTable [256]string
+
+// placeholders is replaced wholesale on registration so readers never lock.
+var placeholders atomic.Pointer[patternTable]
+
+func init() {
+	t := new(patternTable)
+	t['#'] = deci
+	t['?'] = alphadict
+	t['X'] = uhexdict
+	t['*'] = alphanumdict
+	placeholders.Store(t)
+}
+
+// RegisterPlaceholder makes placeholder expand to a random byte of alphabet in
+// Pattern masks. Built-in placeholders (#, ?, X and *) may be overridden.
+// The alphabet must be ASCII, since each placeholder expands to one byte.
+// Passing an empty alphabet is an error; use UnregisterPlaceholder instead.
+func (word) RegisterPlaceholder(placeholder byte, alphabet string) error {
+	if placeholder == patternEscape || placeholder >= 0x80 {
+		return ErrInvalidPlaceholder
+	}
+	if alphabet == "" {
+		return ErrEmptyAlphabet
+	}
+	for i := 0; i < len(alphabet); i++ {
+		if alphabet[i] >= 0x80 {
+			return ErrInvalidPlaceholder
+		}
+	}
+	for {
+		old := placeholders.Load()
+		t := *old
+		t[placeholder] = alphabet
+		if placeholders.CompareAndSwap(old, &t) {
+			return nil
+		}
+	}
+}
+
+// UnregisterPlaceholder makes placeholder a literal character in Pattern masks.
+func (word) UnregisterPlaceholder(placeholder byte) {
+	for {
+		old := placeholders.Load()
+		t := *old
+		t[placeholder] = ""
+		if placeholders.CompareAndSwap(old, &t) {
+			return
+		}
+	}
+}
+
+func fillPattern(out []byte, mask string, rng *wordRNG) []byte {
+	t := placeholders.Load()
+	for i := 0; i < len(mask); i++ {
+		c := mask[i]
+		if c == patternEscape && i+1 < len(mask) {
+			i++
+			out = append(out, mask[i])
+			continue
+		}
+		dict := t[c]
+		if dict == "" {
+			out = append(out, c)
+			continue
+		}
+		out = append(out, dict[uniformUint64n(uint64(len(dict)), rng)])
+	}
+	return out
+}
+
+// Pattern generates a random string from mask, replacing each placeholder with
+// a random character: # is a decimal digit, ? an ASCII letter, X an uppercase
+// hexadecimal digit and * an ASCII letter or digit. A backslash makes the
+// following character literal; every other character is copied as is.
+func (w word) Pattern(mask string) string {
+	if mask == "" {
+		return ""
+	}
+	rng := w.r.source()
+	return string(fillPattern(make([]byte, 0, len(mask)), mask, rng))
+}
+
+// PatternBytes generates a random byte slice from mask using the same rules as Pattern.
+func (w word) PatternBytes(mask string) []byte {
+	if mask == "" {
+		return nil
+	}
+	rng := w.r.source()
+	return fillPattern(make([]byte, 0, len(mask)), mask, rng)
+}
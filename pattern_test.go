@@ -0,0 +1,61 @@
+package randomizer_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/colduction/randomizer"
+)
+
+func TestWordPatternBuiltins(t *testing.T) {
+	check := regexp.MustCompile(`^[0-9]{3}-[a-zA-Z]{3}-[0-9A-F]{4}/[0-9a-zA-Z]{2}#X$`)
+	for range 1000 {
+		s := randomizer.Word.Pattern(`###-???-XXXX/**\#\X`)
+		if !check.MatchString(s) {
+			t.Fatalf("Pattern generated %q", s)
+		}
+	}
+	if got := randomizer.Word.Pattern(""); got != "" {
+		t.Fatalf("Pattern(\"\") = %q, want empty string", got)
+	}
+	if got := randomizer.Word.PatternBytes(""); got != nil {
+		t.Fatalf("PatternBytes(\"\") = %v, want nil", got)
+	}
+	if got := randomizer.Word.Pattern(`ab\`); got != `ab\` {
+		t.Fatalf("Pattern with trailing escape = %q, want %q", got, `ab\`)
+	}
+}
+
+func TestWordPatternRegister(t *testing.T) {
+	if err := randomizer.Word.RegisterPlaceholder('\\', "ab"); err != randomizer.ErrInvalidPlaceholder {
+		t.Fatalf("RegisterPlaceholder('\\\\') error = %v, want ErrInvalidPlaceholder", err)
+	}
+	if err := randomizer.Word.RegisterPlaceholder('@', ""); err != randomizer.ErrEmptyAlphabet {
+		t.Fatalf("RegisterPlaceholder with empty alphabet error = %v, want ErrEmptyAlphabet", err)
+	}
+	if err := randomizer.Word.RegisterPlaceholder('@', "äöü"); err != randomizer.ErrInvalidPlaceholder {
+		t.Fatalf("RegisterPlaceholder with non-ASCII alphabet error = %v, want ErrInvalidPlaceholder", err)
+	}
+	if err := randomizer.Word.RegisterPlaceholder('@', "AEIOU"); err != nil {
+		t.Fatal(err)
+	}
+	defer randomizer.Word.UnregisterPlaceholder('@')
+
+	check := regexp.MustCompile(`^K[AEIOU]{4}$`)
+	for range 1000 {
+		if s := string(randomizer.Word.PatternBytes("K@@@@")); !check.MatchString(s) {
+			t.Fatalf("PatternBytes with custom placeholder generated %q", s)
+		}
+	}
+	randomizer.Word.UnregisterPlaceholder('@')
+	if got := randomizer.Word.Pattern("@"); got != "@" {
+		t.Fatalf("Pattern after unregister = %q, want %q", got, "@")
+	}
+}
+
+func BenchmarkWordPattern(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		benchWordString = randomizer.Word.Pattern("XXXX-XXXX-XXXX-XXXX")
+	}
+}
@@ -0,0 +1,132 @@
+package randomizer
+
+import (
+	"encoding/binary"
+	"io"
+	"time"
+)
+
+// LinkTypeEthernet is the link-layer header type of Ethernet frames such as
+// those built by Network.Packet.
+// ref: https://www.tcpdump.org/linktypes.html
+const LinkTypeEthernet = 1
+
+// DefaultSnapLen is the capture length recorded by the capture writers,
+// matching the libpcap default.
+const DefaultSnapLen = 262144
+
+// PcapWriter writes packets in the classic libpcap file format with
+// microsecond timestamps.
+// ref: https://datatracker.ietf.org/doc/html/draft-ietf-opsawg-pcap
+type PcapWriter struct {
+	w   io.Writer
+	buf []byte
+}
+
+// NewPcapWriter writes the libpcap file header for Ethernet frames to w and
+// returns a writer for the packet records.
+func NewPcapWriter(w io.Writer) (*PcapWriter, error) {
+	var hdr [24]byte
+	le := binary.LittleEndian
+	le.PutUint32(hdr[0:], 0xA1B2C3D4)
+	le.PutUint16(hdr[4:], 2) // version 2.4
+	le.PutUint16(hdr[6:], 4)
+	le.PutUint32(hdr[16:], DefaultSnapLen)
+	le.PutUint32(hdr[20:], LinkTypeEthernet)
+	if _, err := w.Write(hdr[:]); err != nil {
+		return nil, err
+	}
+	return &PcapWriter{w: w}, nil
+}
+
+// WritePacket writes a packet record captured at ts. Frames longer than
+// DefaultSnapLen are truncated while keeping their original length.
+func (pw *PcapWriter) WritePacket(ts time.Time, data []byte) error {
+	caplen := min(len(data), DefaultSnapLen)
+	le := binary.LittleEndian
+	buf := pw.buf[:0]
+	buf = le.AppendUint32(buf, uint32(ts.Unix()))
+	buf = le.AppendUint32(buf, uint32(ts.Nanosecond()/1000))
+	buf = le.AppendUint32(buf, uint32(caplen))
+	buf = le.AppendUint32(buf, uint32(len(data)))
+	buf = append(buf, data[:caplen]...)
+	pw.buf = buf
+	_, err := pw.w.Write(buf)
+	return err
+}
+
+// pcapng block types.
+// ref: https://datatracker.ietf.org/doc/html/draft-ietf-opsawg-pcapng
+const (
+	pcapngSectionHeader  = 0x0A0D0D0A
+	pcapngInterfaceDesc  = 0x00000001
+	pcapngEnhancedPacket = 0x00000006
+	pcapngByteOrderMagic = 0x1A2B3C4D
+	pcapngEPBHeaderLen   = 28
+)
+
+// pcapngPadding aligns packet data to 32 bits.
+var pcapngPadding [3]byte
+
+// PcapngWriter writes packets in the pcapng file format as a single section
+// with one Ethernet interface and microsecond timestamps.
+type PcapngWriter struct {
+	w   io.Writer
+	buf []byte
+}
+
+// NewPcapngWriter writes a section header block and an interface description
+// block for Ethernet frames to w and returns a writer for the packet blocks.
+func NewPcapngWriter(w io.Writer) (*PcapngWriter, error) {
+	le := binary.LittleEndian
+	buf := make([]byte, 0, 48)
+
+	// Section header block with an unspecified section length.
+	buf = le.AppendUint32(buf, pcapngSectionHeader)
+	buf = le.AppendUint32(buf, 28)
+	buf = le.AppendUint32(buf, pcapngByteOrderMagic)
+	buf = le.AppendUint16(buf, 1) // version 1.0
+	buf = le.AppendUint16(buf, 0)
+	buf = le.AppendUint64(buf, ^uint64(0))
+	buf = le.AppendUint32(buf, 28)
+
+	// Interface description block without options, so that timestamps use
+	// the default resolution of microseconds.
+	buf = le.AppendUint32(buf, pcapngInterfaceDesc)
+	buf = le.AppendUint32(buf, 20)
+	buf = le.AppendUint16(buf, LinkTypeEthernet)
+	buf = le.AppendUint16(buf, 0)
+	buf = le.AppendUint32(buf, DefaultSnapLen)
+	buf = le.AppendUint32(buf, 20)
+
+	if _, err := w.Write(buf); err != nil {
+		return nil, err
+	}
+	return &PcapngWriter{w: w, buf: buf}, nil
+}
+
+// WritePacket writes an enhanced packet block captured at ts on the single
+// interface. Frames longer than DefaultSnapLen are truncated while keeping
+// their original length.
+func (pw *PcapngWriter) WritePacket(ts time.Time, data []byte) error {
+	caplen := min(len(data), DefaultSnapLen)
+	padded := (caplen + 3) &^ 3
+	total := uint32(pcapngEPBHeaderLen + padded + 4)
+	usec := uint64(ts.UnixMicro())
+
+	le := binary.LittleEndian
+	buf := pw.buf[:0]
+	buf = le.AppendUint32(buf, pcapngEnhancedPacket)
+	buf = le.AppendUint32(buf, total)
+	buf = le.AppendUint32(buf, 0) // interface ID
+	buf = le.AppendUint32(buf, uint32(usec>>32))
+	buf = le.AppendUint32(buf, uint32(usec))
+	buf = le.AppendUint32(buf, uint32(caplen))
+	buf = le.AppendUint32(buf, uint32(len(data)))
+	buf = append(buf, data[:caplen]...)
+	buf = append(buf, pcapngPadding[:padded-caplen]...)
+	buf = le.AppendUint32(buf, total)
+	pw.buf = buf
+	_, err := pw.w.Write(buf)
+	return err
+}
@@ -0,0 +1,113 @@
+package randomizer_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/colduction/randomizer"
+)
+
+func testFrames(t *testing.T, n int) [][]byte {
+	t.Helper()
+	frames := make([][]byte, n)
+	for i := range frames {
+		f, err := randomizer.Network.Packet(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		frames[i] = f
+	}
+	return frames
+}
+
+func TestPcapWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := randomizer.NewPcapWriter(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	frames := testFrames(t, 10)
+	ts := time.Date(2024, 5, 1, 12, 0, 0, 123456789, time.UTC)
+	for i, f := range frames {
+		if err := w.WritePacket(ts.Add(time.Duration(i)*time.Millisecond), f); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	le := binary.LittleEndian
+	b := buf.Bytes()
+	if le.Uint32(b) != 0xA1B2C3D4 || le.Uint16(b[4:]) != 2 || le.Uint16(b[6:]) != 4 ||
+		le.Uint32(b[16:]) != randomizer.DefaultSnapLen || le.Uint32(b[20:]) != randomizer.LinkTypeEthernet {
+		t.Fatalf("bad file header %x", b[:24])
+	}
+	b = b[24:]
+	for i, f := range frames {
+		want := ts.Add(time.Duration(i) * time.Millisecond)
+		if le.Uint32(b) != uint32(want.Unix()) || le.Uint32(b[4:]) != uint32(want.Nanosecond()/1000) {
+			t.Fatalf("record %d has timestamp %x", i, b[:8])
+		}
+		n := int(le.Uint32(b[8:]))
+		if n != len(f) || le.Uint32(b[12:]) != uint32(len(f)) || !bytes.Equal(b[16:16+n], f) {
+			t.Fatalf("record %d does not match frame", i)
+		}
+		b = b[16+n:]
+	}
+	if len(b) != 0 {
+		t.Fatalf("%d trailing bytes", len(b))
+	}
+}
+
+func TestPcapngWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := randomizer.NewPcapngWriter(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	frames := testFrames(t, 10)
+	ts := time.Date(2024, 5, 1, 12, 0, 0, 123456789, time.UTC)
+	for _, f := range frames {
+		if err := w.WritePacket(ts, f); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	le := binary.LittleEndian
+	b := buf.Bytes()
+	var blocks []uint32
+	var packets [][]byte
+	for len(b) > 0 {
+		typ, n := le.Uint32(b), int(le.Uint32(b[4:]))
+		if n%4 != 0 || n > len(b) || le.Uint32(b[n-4:]) != uint32(n) {
+			t.Fatalf("block %d has bad length %d", len(blocks), n)
+		}
+		switch typ {
+		case 0x0A0D0D0A:
+			if le.Uint32(b[8:]) != 0x1A2B3C4D || le.Uint16(b[12:]) != 1 {
+				t.Fatalf("bad section header %x", b[:n])
+			}
+		case 1:
+			if le.Uint16(b[8:]) != randomizer.LinkTypeEthernet {
+				t.Fatalf("bad interface description %x", b[:n])
+			}
+		case 6:
+			usec := uint64(le.Uint32(b[12:]))<<32 | uint64(le.Uint32(b[16:]))
+			if usec != uint64(ts.UnixMicro()) {
+				t.Fatalf("packet %d has timestamp %d", len(packets), usec)
+			}
+			caplen := int(le.Uint32(b[20:]))
+			packets = append(packets, b[28:28+caplen])
+		}
+		blocks = append(blocks, typ)
+		b = b[n:]
+	}
+	if len(blocks) != 2+len(frames) || blocks[0] != 0x0A0D0D0A || blocks[1] != 1 {
+		t.Fatalf("block types %x", blocks)
+	}
+	for i, f := range frames {
+		if !bytes.Equal(packets[i], f) {
+			t.Fatalf("packet %d does not match frame", i)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package randomizer
+
+import (
+	"iter"
+	"net/netip"
+)
+
+// feistelRounds is the number of Feistel rounds; four rounds of a random round
+// function yield a strong pseudorandom permutation (Luby–Rackoff).
+const feistelRounds = 4
+
+// PrefixPermutation visits every address of a prefix exactly once in a
+// pseudorandom order determined by its seed, without storing visited
+// addresses. The order is a keyed Feistel bijection over the prefix's host
+// bits, so any position can be resumed from. A PrefixPermutation is not safe
+// for concurrent use.
+type PrefixPermutation struct {
+	prefix   netip.Prefix
+	base     uint128
+	hostBits int
+	half     int // bits per Feistel half; 2*half >= hostBits
+	keys     [feistelRounds]uint64
+	seed     uint64
+	pos      uint64
+}
+
+// PermutePrefix returns a permutation of the addresses of p driven by seed.
+// The same prefix and seed always produce the same order.
+func (network) PermutePrefix(p netip.Prefix, seed uint64) (*PrefixPermutation, error) {
+	if !p.IsValid() {
+		return nil, ErrInvalidPrefix
+	}
+	p = p.Masked()
+	pp := &PrefixPermutation{
+		prefix:   p,
+		base:     u128FromAddr(p.Addr()),
+		hostBits: prefixWidth(p) - p.Bits(),
+		seed:     seed,
+	}
+	pp.half = (pp.hostBits + 1) / 2
+	state := seed
+	for i := range pp.keys {
+		state += splitMixGamma
+		pp.keys[i] = splitMix64(state)
+	}
+	return pp, nil
+}
+
+// Prefix returns the permuted prefix.
+func (pp *PrefixPermutation) Prefix() netip.Prefix {
+	return pp.prefix
+}
+
+// Seed returns the seed that determines the order.
+func (pp *PrefixPermutation) Seed() uint64 {
+	return pp.seed
+}
+
+// Position returns the index of the next address Addrs will yield. For
+// prefixes with more than 64 host bits only the first 2^64 positions are
+// addressable.
+func (pp *PrefixPermutation) Position() uint64 {
+	return pp.pos
+}
+
+// Seek sets the index of the next address Addrs will yield, so that an
+// interrupted enumeration can be resumed with the same prefix and seed.
+func (pp *PrefixPermutation) Seek(pos uint64) {
+	pp.pos = pos
+}
+
+// Done reports whether every address has been yielded.
+func (pp *PrefixPermutation) Done() bool {
+	return pp.hostBits < 64 && pp.pos >= 1<<pp.hostBits
+}
+
+// At returns the address at index i of the permutation. i is reduced modulo
+// the number of addresses in the prefix.
+func (pp *PrefixPermutation) At(i uint64) netip.Addr {
+	x := uint128{lo: i}.and(lowMask128(pp.hostBits))
+	return pp.base.or(pp.permute(x)).addr(pp.prefix.Addr().Is4())
+}
+
+// Addrs returns an iterator over the remaining addresses, starting at the
+// current position and advancing it with every address yielded.
+func (pp *PrefixPermutation) Addrs() iter.Seq[netip.Addr] {
+	return func(yield func(netip.Addr) bool) {
+		for !pp.Done() {
+			a := pp.At(pp.pos)
+			pp.pos++
+			if !yield(a) {
+				return
+			}
+			if pp.pos == 0 {
+				// Wrapped around after 2^64 addresses.
+				return
+			}
+		}
+	}
+}
+
+// permute maps x in [0, 2^hostBits) to a unique value in the same range,
+// cycle-walking when the Feistel domain is larger than the host space.
+func (pp *PrefixPermutation) permute(x uint128) uint128 {
+	if pp.hostBits == 0 {
+		return x
+	}
+	limit := lowMask128(pp.hostBits)
+	for {
+		x = pp.feistel(x)
+		if x.cmp(limit) <= 0 {
+			return x
+		}
+	}
+}
+
+func (pp *PrefixPermutation) feistel(x uint128) uint128 {
+	mask := lowMask128(pp.half).lo
+	var l, r uint64
+	if pp.half == 64 {
+		l, r = x.hi, x.lo
+	} else {
+		l, r = x.lo>>pp.half|x.hi<<(64-pp.half), x.lo&mask
+	}
+	for _, k := range pp.keys {
+		l, r = r, l^(splitMix64(r^k)&mask)
+	}
+	if pp.half == 64 {
+		return uint128{l, r}
+	}
+	return uint128{hi: l >> (64 - pp.half), lo: l<<pp.half | r}
+}
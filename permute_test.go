@@ -0,0 +1,111 @@
+package randomizer_test
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/colduction/randomizer"
+)
+
+func TestNetworkPermutePrefixComplete(t *testing.T) {
+	for _, s := range []string{"10.20.0.0/16", "192.0.2.0/29", "192.0.2.5/32", "2001:db8::/117"} {
+		p := netip.MustParsePrefix(s)
+		perm, err := randomizer.Network.PermutePrefix(p, 42)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := 1 << (p.Addr().BitLen() - p.Bits())
+		seen := make(map[netip.Addr]bool, want)
+		for a := range perm.Addrs() {
+			if !p.Contains(a) {
+				t.Fatalf("PermutePrefix(%s) yielded %v outside the prefix", s, a)
+			}
+			if seen[a] {
+				t.Fatalf("PermutePrefix(%s) yielded %v twice", s, a)
+			}
+			seen[a] = true
+		}
+		if len(seen) != want || !perm.Done() {
+			t.Fatalf("PermutePrefix(%s) yielded %d addresses, want %d", s, len(seen), want)
+		}
+	}
+}
+
+func TestNetworkPermutePrefixLarge(t *testing.T) {
+	p := netip.MustParsePrefix("2001:db8:1:2::/64")
+	perm, err := randomizer.Network.PermutePrefix(p, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	seen := make(map[netip.Addr]bool)
+	for a := range perm.Addrs() {
+		if !p.Contains(a) || seen[a] {
+			t.Fatalf("PermutePrefix yielded invalid or repeated address %v", a)
+		}
+		seen[a] = true
+		if len(seen) == 10000 {
+			break
+		}
+	}
+	if perm.Position() != 10000 {
+		t.Fatalf("Position = %d, want 10000", perm.Position())
+	}
+
+	all, err := randomizer.Network.PermutePrefix(netip.MustParsePrefix("::/0"), 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a, b := all.At(1), all.At(2); a == b {
+		t.Fatalf("::/0 permutation mapped two indices to %v", a)
+	}
+}
+
+func TestNetworkPermutePrefixResume(t *testing.T) {
+	p := netip.MustParsePrefix("172.16.0.0/20")
+	first, _ := randomizer.Network.PermutePrefix(p, 99)
+	var want []netip.Addr
+	for a := range first.Addrs() {
+		want = append(want, a)
+	}
+
+	resumed, _ := randomizer.Network.PermutePrefix(p, 99)
+	var got []netip.Addr
+	for a := range resumed.Addrs() {
+		got = append(got, a)
+		if len(got) == 1000 {
+			break
+		}
+	}
+	pos := resumed.Position()
+	again, _ := randomizer.Network.PermutePrefix(p, resumed.Seed())
+	again.Seek(pos)
+	for a := range again.Addrs() {
+		got = append(got, a)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("resumed enumeration yielded %d addresses, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("resumed enumeration differs at %d: %v != %v", i, got[i], want[i])
+		}
+	}
+
+	other, _ := randomizer.Network.PermutePrefix(p, 100)
+	if other.At(0) == first.At(0) && other.At(1) == first.At(1) && other.At(2) == first.At(2) {
+		t.Fatal("different seeds produced the same order")
+	}
+	if _, err := randomizer.Network.PermutePrefix(netip.Prefix{}, 1); err != randomizer.ErrInvalidPrefix {
+		t.Fatalf("PermutePrefix with invalid prefix error = %v, want ErrInvalidPrefix", err)
+	}
+}
+
+func BenchmarkNetworkPermutePrefix(b *testing.B) {
+	perm, _ := randomizer.Network.PermutePrefix(netip.MustParsePrefix("2001:db8::/64"), 1)
+	var i uint64
+	b.ReportAllocs()
+	for b.Loop() {
+		benchAddr = perm.At(i)
+		i++
+	}
+}
@@ -0,0 +1,112 @@
+package randomizer
+
+import (
+	"errors"
+	"net/netip"
+	"slices"
+)
+
+var (
+	// ErrInvalidPortRange is returned when a custom port range has Min above Max.
+	ErrInvalidPortRange = errors.New("randomizer: invalid port range")
+	// ErrPortsExhausted is returned when exclusions leave no port to choose from.
+	ErrPortsExhausted = errors.New("randomizer: no ports left in range")
+)
+
+// PortRange selects a category of transport-layer ports.
+// ref: https://datatracker.ietf.org/doc/html/rfc6335#section-6
+type PortRange uint8
+
+const (
+	// AnyPorts is every usable port, 1-65535.
+	AnyPorts PortRange = iota
+	// WellKnownPorts are the IANA system ports, 0-1023.
+	WellKnownPorts
+	// RegisteredPorts are the IANA user ports, 1024-49151.
+	RegisteredPorts
+	// DynamicPorts are the IANA dynamic and private ports, 49152-65535.
+	DynamicPorts
+	// LinuxEphemeralPorts is the default Linux ip_local_port_range, 32768-60999.
+	LinuxEphemeralPorts
+	// CustomPorts uses PortOptions.Min and PortOptions.Max.
+	CustomPorts
+	SystemPorts    PortRange = WellKnownPorts
+	UserPorts      PortRange = RegisteredPorts
+	EphemeralPorts PortRange = DynamicPorts
+)
+
+var portRanges = [...][2]uint16{
+	AnyPorts:            {1, 65535},
+	WellKnownPorts:      {0, 1023},
+	RegisteredPorts:     {1024, 49151},
+	DynamicPorts:        {49152, 65535},
+	LinuxEphemeralPorts: {32768, 60999},
+}
+
+// PortOptions configures Network.Port.
+type PortOptions struct {
+	// Range selects the port category.
+	Range PortRange
+	// Min and Max bound the ports, inclusive, when Range is CustomPorts.
+	Min, Max uint16
+	// Exclude lists ports that are never returned.
+	Exclude []uint16
+}
+
+func pickPort(opts *PortOptions, rng *wordRNG) (uint16, error) {
+	if opts == nil {
+		return randomPort(rng), nil
+	}
+	var lo, hi uint16
+	switch {
+	case opts.Range == CustomPorts:
+		lo, hi = opts.Min, opts.Max
+		if lo > hi {
+			return 0, ErrInvalidPortRange
+		}
+	case int(opts.Range) < len(portRanges):
+		lo, hi = portRanges[opts.Range][0], portRanges[opts.Range][1]
+	default:
+		return 0, ErrInvalidPortRange
+	}
+
+	var excluded []uint16
+	for _, p := range opts.Exclude {
+		if p >= lo && p <= hi {
+			excluded = append(excluded, p)
+		}
+	}
+	slices.Sort(excluded)
+	excluded = slices.Compact(excluded)
+
+	allowed := uint64(hi-lo) + 1 - uint64(len(excluded))
+	if allowed == 0 {
+		return 0, ErrPortsExhausted
+	}
+	v := uint64(lo) + uniformUint64n(allowed, rng)
+	for _, p := range excluded {
+		if uint64(p) > v {
+			break
+		}
+		v++
+	}
+	return uint16(v), nil
+}
+
+// Port generates a random port chosen uniformly from the range selected by
+// opts, skipping excluded ports. A nil opts draws from AnyPorts.
+func (n network) Port(opts *PortOptions) (uint16, error) {
+	rng := n.r.source()
+	return pickPort(opts, rng)
+}
+
+// AddrPort combines addr, typically produced by another Network generator,
+// with a random port selected by opts.
+func (n network) AddrPort(addr netip.Addr, opts *PortOptions) (netip.AddrPort, error) {
+	rng := n.r.source()
+	port, err := pickPort(opts, rng)
+	if err != nil {
+		return netip.AddrPort{}, err
+	}
+	return netip.AddrPortFrom(addr, port), nil
+}
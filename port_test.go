@@ -0,0 +1,77 @@
+package randomizer_test
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/colduction/randomizer"
+)
+
+func TestNetworkPortRanges(t *testing.T) {
+	cases := []struct {
+		opts   *randomizer.PortOptions
+		lo, hi uint16
+	}{
+		{nil, 1, 65535},
+		{&randomizer.PortOptions{Range: randomizer.WellKnownPorts}, 0, 1023},
+		{&randomizer.PortOptions{Range: randomizer.RegisteredPorts}, 1024, 49151},
+		{&randomizer.PortOptions{Range: randomizer.DynamicPorts}, 49152, 65535},
+		{&randomizer.PortOptions{Range: randomizer.LinuxEphemeralPorts}, 32768, 60999},
+		{&randomizer.PortOptions{Range: randomizer.CustomPorts, Min: 8000, Max: 8080}, 8000, 8080},
+	}
+	for _, tc := range cases {
+		for range 2000 {
+			p, err := randomizer.Network.Port(tc.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if p < tc.lo || p > tc.hi {
+				t.Fatalf("Port(%+v) = %d, want in [%d, %d]", tc.opts, p, tc.lo, tc.hi)
+			}
+		}
+	}
+}
+
+func TestNetworkPortExclude(t *testing.T) {
+	opts := &randomizer.PortOptions{Range: randomizer.CustomPorts, Min: 10, Max: 19, Exclude: []uint16{10, 12, 12, 19, 25}}
+	seen := make(map[uint16]int)
+	for range 7000 {
+		p, err := randomizer.Network.Port(opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		seen[p]++
+	}
+	for p := uint16(10); p <= 19; p++ {
+		excluded := p == 10 || p == 12 || p == 19
+		if excluded && seen[p] > 0 {
+			t.Fatalf("excluded port %d was returned", p)
+		}
+		if !excluded && (seen[p] < 700 || seen[p] > 1300) {
+			t.Fatalf("port %d returned %d times, want about 1000", p, seen[p])
+		}
+	}
+
+	opts = &randomizer.PortOptions{Range: randomizer.CustomPorts, Min: 80, Max: 81, Exclude: []uint16{80, 81}}
+	if _, err := randomizer.Network.Port(opts); err != randomizer.ErrPortsExhausted {
+		t.Fatalf("Port with all ports excluded error = %v, want ErrPortsExhausted", err)
+	}
+	opts = &randomizer.PortOptions{Range: randomizer.CustomPorts, Min: 81, Max: 80}
+	if _, err := randomizer.Network.Port(opts); err != randomizer.ErrInvalidPortRange {
+		t.Fatalf("Port with Min > Max error = %v, want ErrInvalidPortRange", err)
+	}
+}
+
+func TestNetworkAddrPort(t *testing.T) {
+	addr := randomizer.Network.Addr4Category(randomizer.IPv4Private)
+	ap, err := randomizer.Network.AddrPort(addr, &randomizer.PortOptions{Range: randomizer.DynamicPorts})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ap.Addr() != addr || ap.Port() < 49152 {
+		t.Fatalf("AddrPort = %v", ap)
+	}
+	if got := netip.AddrPortFrom(addr, ap.Port()).String(); got != ap.String() {
+		t.Fatalf("AddrPort string = %q, want %q", ap.String(), got)
+	}
+}
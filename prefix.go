@@ -0,0 +1,75 @@
+package randomizer
+
+import (
+	"errors"
+	"net/netip"
+	"slices"
+)
+
+var (
+	// ErrInvalidPrefix is returned when a prefix is the zero value or otherwise invalid.
+	ErrInvalidPrefix = errors.New("randomizer: invalid prefix")
+	// ErrPrefixExhausted is returned when exclusions leave no address to choose from.
+	ErrPrefixExhausted = errors.New("randomizer: no addresses left in prefix")
+)
+
+// PrefixOptions configures Network.AddrInPrefix.
+type PrefixOptions struct {
+	// ExcludeNetworkBroadcast skips the first (network) and last (broadcast)
+	// address of IPv4 prefixes shorter than /31. It has no effect on IPv6.
+	ExcludeNetworkBroadcast bool
+	// ExcludeSpecialPurpose skips every block of the IANA special-purpose
+	// registry of the prefix's address family.
+	ExcludeSpecialPurpose bool
+	// Exclude lists sub-prefixes whose addresses are never returned. Prefixes
+	// of the other address family are ignored.
+	Exclude []netip.Prefix
+}
+
+// addrInPrefix picks an address uniformly from p minus the exclusions in opts.
+func addrInPrefix(p netip.Prefix, opts *PrefixOptions, rng *wordRNG) (netip.Addr, error) {
+	if !p.IsValid() {
+		return netip.Addr{}, ErrInvalidPrefix
+	}
+	p = p.Masked()
+	is4 := p.Addr().Is4()
+	hostBits := prefixWidth(p) - p.Bits()
+
+	var exclude []netip.Prefix
+	if opts != nil {
+		exclude = opts.Exclude
+		if opts.ExcludeNetworkBroadcast && is4 && hostBits >= 2 {
+			whole := prefixInterval(p)
+			exclude = append(slices.Clip(exclude),
+				netip.PrefixFrom(whole.lo.addr(true), 32),
+				netip.PrefixFrom(whole.hi.addr(true), 32))
+		}
+		if opts.ExcludeSpecialPurpose {
+			special := ipv6SpecialPurpose
+			if is4 {
+				special = ipv4SpecialPurpose
+			}
+			exclude = append(slices.Clip(exclude), special...)
+		}
+	}
+	if len(exclude) == 0 {
+		return u128FromAddr(p.Addr()).or(randomBits128(hostBits, rng)).addr(is4), nil
+	}
+	space := newAddrSpace([]netip.Prefix{p}, exclude)
+	if space.empty() {
+		return netip.Addr{}, ErrPrefixExhausted
+	}
+	if space.total.isZero() {
+		// Nothing of the other family was excluded from ::/0.
+		return randomBits128(hostBits, rng).addr(is4), nil
+	}
+	return space.pick(rng), nil
+}
+
+// AddrInPrefix generates a random address inside p, chosen uniformly among the
+// addresses not removed by opts. It supports every prefix length from /0 to
+// /32 for IPv4 and /128 for IPv6. A nil opts excludes nothing.
+func (n network) AddrInPrefix(p netip.Prefix, opts *PrefixOptions) (netip.Addr, error) {
+	rng := n.r.source()
+	return addrInPrefix(p, opts, rng)
+}
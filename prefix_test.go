@@ -0,0 +1,96 @@
+package randomizer_test
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/colduction/randomizer"
+)
+
+func TestNetworkAddrInPrefixContains(t *testing.T) {
+	prefixes := []string{
+		"10.20.0.0/16", "0.0.0.0/0", "192.0.2.7/32", "10.20.30.41/24",
+		"2001:db8::/48", "::/0", "2001:db8::1/128", "fe80::/10",
+	}
+	for _, s := range prefixes {
+		p := netip.MustParsePrefix(s)
+		for range 1000 {
+			a, err := randomizer.Network.AddrInPrefix(p, nil)
+			if err != nil {
+				t.Fatalf("AddrInPrefix(%s) error: %v", s, err)
+			}
+			if !p.Masked().Contains(a) {
+				t.Fatalf("AddrInPrefix(%s) returned %v outside the prefix", s, a)
+			}
+		}
+	}
+}
+
+func TestNetworkAddrInPrefixExclusions(t *testing.T) {
+	p := netip.MustParsePrefix("192.168.1.0/28")
+	opts := &randomizer.PrefixOptions{
+		ExcludeNetworkBroadcast: true,
+		Exclude: []netip.Prefix{
+			netip.MustParsePrefix("192.168.1.4/30"),
+			netip.MustParsePrefix("192.168.1.6/31"),
+			netip.MustParsePrefix("192.168.0.1/32"),
+			netip.MustParsePrefix("2001:db8::/32"),
+		},
+	}
+	seen := make(map[netip.Addr]int)
+	for range 20000 {
+		a, err := randomizer.Network.AddrInPrefix(p, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		seen[a]++
+	}
+	for i := range 16 {
+		a := netip.AddrFrom4([4]byte{192, 168, 1, byte(i)})
+		excluded := i == 0 || i == 15 || (i >= 4 && i <= 7)
+		if excluded && seen[a] > 0 {
+			t.Fatalf("excluded address %v was returned", a)
+		}
+		if !excluded && (seen[a] < 1500 || seen[a] > 2500) {
+			t.Fatalf("address %v returned %d times, want about 2000", a, seen[a])
+		}
+	}
+}
+
+func TestNetworkAddrInPrefixLargeExclusion(t *testing.T) {
+	p := netip.MustParsePrefix("::/0")
+	opts := &randomizer.PrefixOptions{Exclude: []netip.Prefix{netip.MustParsePrefix("8000::/1")}}
+	for range 1000 {
+		a, err := randomizer.Network.AddrInPrefix(p, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if a.As16()[0]&0x80 != 0 {
+			t.Fatalf("AddrInPrefix returned excluded address %v", a)
+		}
+	}
+}
+
+func TestNetworkAddrInPrefixErrors(t *testing.T) {
+	if _, err := randomizer.Network.AddrInPrefix(netip.Prefix{}, nil); err != randomizer.ErrInvalidPrefix {
+		t.Fatalf("zero prefix error = %v, want ErrInvalidPrefix", err)
+	}
+	p := netip.MustParsePrefix("10.0.0.0/24")
+	opts := &randomizer.PrefixOptions{Exclude: []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}}
+	if _, err := randomizer.Network.AddrInPrefix(p, opts); err != randomizer.ErrPrefixExhausted {
+		t.Fatalf("fully excluded prefix error = %v, want ErrPrefixExhausted", err)
+	}
+	p = netip.MustParsePrefix("10.0.0.0/31")
+	opts = &randomizer.PrefixOptions{ExcludeNetworkBroadcast: true}
+	if _, err := randomizer.Network.AddrInPrefix(p, opts); err != nil {
+		t.Fatalf("/31 with ExcludeNetworkBroadcast error: %v", err)
+	}
+}
+
+func BenchmarkNetworkAddrInPrefix(b *testing.B) {
+	p := netip.MustParsePrefix("2001:db8::/48")
+	b.ReportAllocs()
+	for b.Loop() {
+		benchAddr, _ = randomizer.Network.AddrInPrefix(p, nil)
+	}
+}
@@ -0,0 +1,145 @@
+package proptest
+
+import (
+	"fmt"
+	"runtime/debug"
+	"testing"
+
+	"github.com/colduction/randomizer"
+)
+
+// Defaults used by Check when Config leaves a field zero.
+const (
+	DefaultRuns       = 100
+	DefaultMaxShrinks = 1000
+)
+
+// Config configures CheckWith.
+type Config struct {
+	// Runs is the number of generated inputs; zero means DefaultRuns.
+	Runs int
+	// Seed makes the run reproducible; zero picks a random seed. The seed of
+	// a failing run is included in its report.
+	Seed uint64
+	// MaxShrinks bounds the shrink candidates tried after a failure; zero
+	// means DefaultMaxShrinks.
+	MaxShrinks int
+}
+
+// Check tests that property holds for inputs drawn from gen, as CheckWith
+// with the default Config.
+func Check[T any](t testing.TB, gen Generator[T], property func(T) bool) {
+	t.Helper()
+	CheckWith(t, nil, gen, property)
+}
+
+// CheckWith runs property on inputs drawn from gen with growing sizes. A
+// property fails by returning false or panicking. The first failing input is
+// shrunk to a minimal one, and the test fails with both inputs and the seed,
+// which reproduces the run when set in Config.Seed.
+func CheckWith[T any](t testing.TB, cfg *Config, gen Generator[T], property func(T) bool) {
+	t.Helper()
+	var c Config
+	if cfg != nil {
+		c = *cfg
+	}
+	if c.Runs <= 0 {
+		c.Runs = DefaultRuns
+	}
+	if c.MaxShrinks <= 0 {
+		c.MaxShrinks = DefaultMaxShrinks
+	}
+	if c.Seed == 0 {
+		c.Seed = randomizer.Uint[uint64]() | 1
+	}
+
+	r := randomizer.NewRand(c.Seed)
+	for run := range c.Runs {
+		size := run * MaxSize / max(c.Runs-1, 1)
+		tr, ok := generate(gen, r, size)
+		if !ok {
+			t.Fatalf("proptest: filter rejected %d consecutive values on run %d (seed %#x)", maxFilterTries, run+1, c.Seed)
+			return
+		}
+		reason := failure(property, tr.value)
+		if reason == "" {
+			continue
+		}
+		minimal, shrinks, reason := shrink(tr, reason, property, c.MaxShrinks)
+		t.Fatalf("proptest: property failed on run %d (seed %#x) after %d shrinks\nminimal input:  %s\noriginal input: %s\nfailure: %s",
+			run+1, c.Seed, shrinks, describe(minimal), describe(tr.value), reason)
+		return
+	}
+}
+
+func generate[T any](gen Generator[T], r *randomizer.Rand, size int) (tr tree[T], ok bool) {
+	defer func() {
+		if v := recover(); v != nil {
+			if _, exhausted := v.(filterExhausted); !exhausted {
+				panic(v)
+			}
+			ok = false
+		}
+	}()
+	return gen.run(r, size), true
+}
+
+// failure runs property on v and returns "" if it holds, or else how it
+// failed, with the value and stack of a panic.
+func failure[T any](property func(T) bool, v T) (reason string) {
+	defer func() {
+		if p := recover(); p != nil {
+			reason = fmt.Sprintf("panic: %v\n\n%s", p, debug.Stack())
+		}
+	}()
+	if property(v) {
+		return ""
+	}
+	return "property returned false"
+}
+
+// shrink greedily follows the first failing candidate of each tree until none
+// fails or the budget is spent, returning the smallest failing value, the
+// number of successful shrink steps and how that value failed, starting from
+// tr failing with reason.
+func shrink[T any](tr tree[T], reason string, property func(T) bool, budget int) (T, int, string) {
+	steps := 0
+	for budget > 0 {
+		next, found := tr, false
+		for c := range tr.shrinks {
+			if budget--; budget < 0 {
+				break
+			}
+			if r := failure(property, c.value); r != "" {
+				next, found, reason = c, true, r
+				break
+			}
+		}
+		if !found {
+			break
+		}
+		tr = next
+		steps++
+	}
+	return tr.value, steps, reason
+}
+
+// describe formats v for a failure report: with its String method if it has
+// one, and as Go syntax otherwise.
+func describe(v any) string {
+	if s, ok := v.(fmt.Stringer); ok {
+		return s.String()
+	}
+	return fmt.Sprintf("%#v", v)
+}
+
+// Sample returns n values drawn from gen with sizes growing up to MaxSize,
+// which helps when inspecting what a generator produces.
+func Sample[T any](gen Generator[T], seed uint64, n int) []T {
+	r := randomizer.NewRand(seed)
+	out := make([]T, n)
+	for i := range out {
+		out[i] = gen.Generate(r, i*MaxSize/max(n-1, 1))
+	}
+	return out
+}
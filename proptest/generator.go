@@ -0,0 +1,468 @@
+// Package proptest is a property-based testing framework built on the
+// randomizer package. Generators produce random values together with the
+// ways each value can be made simpler, so a failing input found by Check is
+// shrunk automatically, also through Map, Filter, Bind and the collection
+// combinators. Generators such as Hex, Regex, Domain and AddrInPrefix wrap
+// the Word and Network generators of randomizer, drawing from the seeded
+// Rand of the run.
+package proptest
+
+import (
+	"iter"
+	"math"
+	"net/netip"
+
+	"github.com/colduction/randomizer"
+)
+
+// MaxSize is the largest size passed to generators. Check grows the size from
+// 0 to MaxSize over its runs, so early runs try small collections.
+const MaxSize = 100
+
+// maxFilterTries bounds the attempts Filter makes to find an accepted value.
+const maxFilterTries = 100
+
+// tree is a generated value and its shrink candidates, simplest first.
+type tree[T any] struct {
+	value   T
+	shrinks iter.Seq[tree[T]]
+}
+
+func leaf[T any](v T) tree[T] {
+	return tree[T]{value: v, shrinks: func(func(tree[T]) bool) {}}
+}
+
+// Generator produces random values of type T along with their shrinks.
+// Generators are immutable and may be shared between tests.
+type Generator[T any] struct {
+	run func(r *randomizer.Rand, size int) tree[T]
+}
+
+// Generate returns a value drawn from r at the given size, which is clamped
+// to [0, MaxSize]. It panics if a Filter rejects too many values in a row.
+func (g Generator[T]) Generate(r *randomizer.Rand, size int) T {
+	return g.run(r, min(max(size, 0), MaxSize)).value
+}
+
+// filterExhausted is the panic value of a Filter that keeps rejecting values.
+type filterExhausted struct{}
+
+// Just returns a generator that always produces v.
+func Just[T any](v T) Generator[T] {
+	return Generator[T]{run: func(*randomizer.Rand, int) tree[T] { return leaf(v) }}
+}
+
+// Map returns a generator applying f to the values of g. Shrinking happens on
+// the values of g, so f need not be invertible.
+func Map[T, U any](g Generator[T], f func(T) U) Generator[U] {
+	return Generator[U]{run: func(r *randomizer.Rand, size int) tree[U] {
+		return mapTree(g.run(r, size), f)
+	}}
+}
+
+func mapTree[T, U any](t tree[T], f func(T) U) tree[U] {
+	return tree[U]{value: f(t.value), shrinks: func(yield func(tree[U]) bool) {
+		for c := range t.shrinks {
+			if !yield(mapTree(c, f)) {
+				return
+			}
+		}
+	}}
+}
+
+// Filter returns a generator producing only the values of g for which keep
+// returns true; shrinks are filtered the same way. Generation panics when
+// 100 consecutive values are rejected, which Check reports as a failure.
+func Filter[T any](g Generator[T], keep func(T) bool) Generator[T] {
+	return Generator[T]{run: func(r *randomizer.Rand, size int) tree[T] {
+		for range maxFilterTries {
+			if t := g.run(r, size); keep(t.value) {
+				return filterTree(t, keep)
+			}
+		}
+		panic(filterExhausted{})
+	}}
+}
+
+func filterTree[T any](t tree[T], keep func(T) bool) tree[T] {
+	return tree[T]{value: t.value, shrinks: func(yield func(tree[T]) bool) {
+		for c := range t.shrinks {
+			if keep(c.value) && !yield(filterTree(c, keep)) {
+				return
+			}
+		}
+	}}
+}
+
+// Bind returns a generator that draws a value from g and then a value from
+// the generator f returns for it. Shrinking first simplifies the value of g,
+// regenerating the dependent value from the same randomness, and then the
+// dependent value itself.
+func Bind[T, U any](g Generator[T], f func(T) Generator[U]) Generator[U] {
+	return Generator[U]{run: func(r *randomizer.Rand, size int) tree[U] {
+		return bindTree(g.run(r, size), f, r.Uint64(), size)
+	}}
+}
+
+func bindTree[T, U any](outer tree[T], f func(T) Generator[U], seed uint64, size int) tree[U] {
+	inner := f(outer.value).run(randomizer.NewRand(seed), size)
+	return tree[U]{value: inner.value, shrinks: func(yield func(tree[U]) bool) {
+		for c := range outer.shrinks {
+			if !yield(bindTree(c, f, seed, size)) {
+				return
+			}
+		}
+		for c := range inner.shrinks {
+			if !yield(c) {
+				return
+			}
+		}
+	}}
+}
+
+// OneOf returns a generator choosing uniformly among gens. Values shrink
+// toward those of earlier generators. It panics if gens is empty.
+func OneOf[T any](gens ...Generator[T]) Generator[T] {
+	if len(gens) == 0 {
+		panic("proptest: OneOf requires at least one generator")
+	}
+	return Bind(Int(0, len(gens)-1), func(i int) Generator[T] { return gens[i] })
+}
+
+// SliceOf returns a generator of slices of values from g. Lengths lie in
+// [minLen, maxLen] and grow with the size, reaching maxLen at MaxSize.
+// Slices shrink by dropping runs of elements, then by shrinking elements.
+func SliceOf[T any](g Generator[T], minLen, maxLen int) Generator[[]T] {
+	minLen = max(minLen, 0)
+	maxLen = max(maxLen, minLen)
+	return Generator[[]T]{run: func(r *randomizer.Rand, size int) tree[[]T] {
+		hi := minLen + (maxLen-minLen)*size/MaxSize
+		elems := make([]tree[T], minLen+r.IntN(hi-minLen+1))
+		for i := range elems {
+			elems[i] = g.run(r, size)
+		}
+		return sliceTree(elems, minLen)
+	}}
+}
+
+func sliceTree[T any](elems []tree[T], minLen int) tree[[]T] {
+	value := make([]T, len(elems))
+	for i, e := range elems {
+		value[i] = e.value
+	}
+	return tree[[]T]{value: value, shrinks: func(yield func(tree[[]T]) bool) {
+		// Drop chunks, largest first: half the slice, a quarter, and so on.
+		for k := len(elems) - minLen; k > 0; k /= 2 {
+			for start := 0; start+k <= len(elems); start += k {
+				rest := make([]tree[T], 0, len(elems)-k)
+				rest = append(append(rest, elems[:start]...), elems[start+k:]...)
+				if !yield(sliceTree(rest, minLen)) {
+					return
+				}
+			}
+		}
+		for i, e := range elems {
+			for c := range e.shrinks {
+				next := make([]tree[T], len(elems))
+				copy(next, elems)
+				next[i] = c
+				if !yield(sliceTree(next, minLen)) {
+					return
+				}
+			}
+		}
+	}}
+}
+
+// MapOf returns a generator of maps with keys from k and values from v.
+// Sizes lie in [0, maxLen] as with SliceOf; duplicate keys collapse, so maps
+// may be smaller than the drawn length.
+func MapOf[K comparable, V any](k Generator[K], v Generator[V], maxLen int) Generator[map[K]V] {
+	type entry struct {
+		key K
+		val V
+	}
+	pair := Bind(k, func(key K) Generator[entry] {
+		return Map(v, func(val V) entry { return entry{key, val} })
+	})
+	return Map(SliceOf(pair, 0, maxLen), func(entries []entry) map[K]V {
+		m := make(map[K]V, len(entries))
+		for _, e := range entries {
+			m[e.key] = e.val
+		}
+		return m
+	})
+}
+
+// Integer is the set of integer types accepted by Int.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Int returns a generator of integers in [lo, hi], shrinking toward zero, or
+// toward the bound closest to zero when zero is out of range. The bounds are
+// swapped if lo > hi.
+func Int[T Integer](lo, hi T) Generator[T] {
+	if lo > hi {
+		lo, hi = hi, lo
+	}
+	var target T
+	switch {
+	case target < lo:
+		target = lo
+	case target > hi:
+		target = hi
+	}
+	return Generator[T]{run: func(r *randomizer.Rand, _ int) tree[T] {
+		span := uint64(hi) - uint64(lo)
+		off := r.Uint64()
+		if span != ^uint64(0) {
+			off = r.Uint64n(span + 1)
+		}
+		return intTree(T(uint64(lo)+off), target)
+	}}
+}
+
+// intTree shrinks x toward target by halving the distance between them.
+func intTree[T Integer](x, target T) tree[T] {
+	return tree[T]{value: x, shrinks: func(yield func(tree[T]) bool) {
+		// x and target are on the same side of zero or target is zero, so
+		// their difference cannot overflow.
+		for d := x - target; d != 0; d /= 2 {
+			if !yield(intTree(x-d, target)) {
+				return
+			}
+		}
+	}}
+}
+
+// Float64 returns a generator of floats in [lo, hi), shrinking toward zero or
+// the bound closest to it, then toward integral values.
+func Float64(lo, hi float64) Generator[float64] {
+	if lo > hi {
+		lo, hi = hi, lo
+	}
+	target := min(max(0, lo), hi)
+	return Generator[float64]{run: func(r *randomizer.Rand, _ int) tree[float64] {
+		return floatTree(lo+(hi-lo)*r.Float64(), target, 0)
+	}}
+}
+
+// floatTree shrinks x toward target; depth bounds the halving steps, since
+// floats only converge after many of them.
+func floatTree(x, target float64, depth int) tree[float64] {
+	const maxDepth = 64
+	return tree[float64]{value: x, shrinks: func(yield func(tree[float64]) bool) {
+		if x == target || depth >= maxDepth {
+			return
+		}
+		if !yield(floatTree(target, target, depth+1)) {
+			return
+		}
+		if t := math.Trunc(x); t != x && (t-target)*(x-target) >= 0 {
+			if !yield(floatTree(t, target, depth+1)) {
+				return
+			}
+		}
+		if mid := target + (x-target)/2; mid != x && mid != target {
+			yield(floatTree(mid, target, depth+1))
+		}
+	}}
+}
+
+// Bool returns a generator of booleans, shrinking toward false.
+func Bool() Generator[bool] {
+	return Generator[bool]{run: func(r *randomizer.Rand, _ int) tree[bool] {
+		if r.Uint64()&1 == 0 {
+			return leaf(false)
+		}
+		return tree[bool]{value: true, shrinks: func(yield func(tree[bool]) bool) { yield(leaf(false)) }}
+	}}
+}
+
+// Alphabets for StringOf.
+const (
+	Alpha        = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	Alphanumeric = "abcdefghijklmnopqrstuvwxyz0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	Digits       = "0123456789"
+	HexDigits    = "0123456789abcdef"
+)
+
+// StringOf returns a generator of strings of runes from alphabet with lengths
+// in [minLen, maxLen] measured in runes, growing with the size like SliceOf.
+// Strings shrink to shorter ones and their runes toward the first rune of
+// alphabet. It panics if alphabet is empty.
+func StringOf(alphabet string, minLen, maxLen int) Generator[string] {
+	runes := []rune(alphabet)
+	if len(runes) == 0 {
+		panic("proptest: StringOf requires a non-empty alphabet")
+	}
+	char := Map(Int(0, len(runes)-1), func(i int) rune { return runes[i] })
+	return Map(SliceOf(char, minLen, maxLen), func(s []rune) string { return string(s) })
+}
+
+// String returns a generator of alphanumeric strings of up to maxLen bytes.
+func String(maxLen int) Generator[string] {
+	return StringOf(Alphanumeric, 0, maxLen)
+}
+
+// Addr4 returns a generator of IPv4 addresses, shrinking toward simpler
+// addresses with fewer and smaller non-zero octets, such as 10.0.0.0.
+func Addr4() Generator[netip.Addr] {
+	return addrGen(4)
+}
+
+// Addr6 returns a generator of IPv6 addresses, shrinking toward simpler
+// addresses with fewer and smaller non-zero bytes, such as 2001::.
+func Addr6() Generator[netip.Addr] {
+	return addrGen(16)
+}
+
+func addrGen(n int) Generator[netip.Addr] {
+	return Generator[netip.Addr]{run: func(r *randomizer.Rand, _ int) tree[netip.Addr] {
+		var b [16]byte
+		r.Read(b[:n])
+		return addrTree(b, n, new([16]byte))
+	}}
+}
+
+// addrTree shrinks the first n bytes of b, leaving the bits set in keep
+// unchanged.
+func addrTree(b [16]byte, n int, keep *[16]byte) tree[netip.Addr] {
+	a := netip.AddrFrom16(b)
+	if n == 4 {
+		a = netip.AddrFrom4([4]byte(b[:4]))
+	}
+	shrink := func(c [16]byte, yield func(tree[netip.Addr]) bool) bool {
+		for i := range n {
+			c[i] = c[i]&^keep[i] | b[i]&keep[i]
+		}
+		return c == b || yield(addrTree(c, n, keep))
+	}
+	return tree[netip.Addr]{value: a, shrinks: func(yield func(tree[netip.Addr]) bool) {
+		// Zero every byte after the first k, keeping the network part.
+		for k := 0; k < n; k++ {
+			c := b
+			clear(c[k:n])
+			if !shrink(c, yield) {
+				return
+			}
+		}
+		// Then shrink single bytes toward zero.
+		for i := range n {
+			for d := b[i]; d != 0; d /= 2 {
+				c := b
+				c[i] -= d
+				if !shrink(c, yield) {
+					return
+				}
+			}
+		}
+	}}
+}
+
+// AddrInPrefix returns a generator of addresses in p drawn by
+// Network.AddrInPrefix, shrinking the host bits toward the network address.
+// It panics if p is not valid.
+func AddrInPrefix(p netip.Prefix) Generator[netip.Addr] {
+	if !p.IsValid() {
+		panic("proptest: AddrInPrefix requires a valid prefix")
+	}
+	p = p.Masked()
+	keep := new([16]byte)
+	for i := range p.Bits() {
+		keep[i/8] |= 0x80 >> (i % 8)
+	}
+	n := p.Addr().BitLen() / 8
+	return Generator[netip.Addr]{run: func(r *randomizer.Rand, _ int) tree[netip.Addr] {
+		a, _ := r.Network().AddrInPrefix(p, nil)
+		var b [16]byte
+		copy(b[:], a.AsSlice())
+		return addrTree(b, n, keep)
+	}}
+}
+
+// FromRand returns a generator of the values f draws from r, for wrapping
+// the randomizer generators, as in
+//
+//	proptest.FromRand(func(r *randomizer.Rand) string { return r.Word().IBAN("DE") })
+//
+// The values do not shrink.
+func FromRand[T any](f func(r *randomizer.Rand) T) Generator[T] {
+	return Generator[T]{run: func(r *randomizer.Rand, _ int) tree[T] { return leaf(f(r)) }}
+}
+
+// Addr4Category returns a generator of IPv4 addresses drawn by
+// Network.Addr4Category. The values do not shrink.
+func Addr4Category(category randomizer.IPv4Category) Generator[netip.Addr] {
+	return FromRand(func(r *randomizer.Rand) netip.Addr { return r.Network().Addr4Category(category) })
+}
+
+// Addr6Unicast returns a generator of IPv6 unicast addresses drawn by
+// Network.Addr6Unicast. The values do not shrink.
+func Addr6Unicast(unicastType randomizer.UnicastType) Generator[netip.Addr] {
+	return FromRand(func(r *randomizer.Rand) netip.Addr { return r.Network().Addr6Unicast(unicastType) })
+}
+
+// Domain returns a generator of domain names drawn by Network.Domain with
+// opts. The values do not shrink. It panics if opts has an invalid TLD.
+func Domain(opts *randomizer.DomainOptions) Generator[string] {
+	if randomizer.Network.Domain(opts) == "" {
+		panic("proptest: Domain requires a valid TLD")
+	}
+	return FromRand(func(r *randomizer.Rand) string { return r.Network().Domain(opts) })
+}
+
+// Email returns a generator of email addresses drawn by Network.Email with
+// opts. The values do not shrink. It panics if opts has an invalid TLD.
+func Email(opts *randomizer.EmailOptions) Generator[string] {
+	if randomizer.Network.Email(opts) == "" {
+		panic("proptest: Email requires a valid TLD")
+	}
+	return FromRand(func(r *randomizer.Rand) string { return r.Network().Email(opts) })
+}
+
+// Hex returns a generator of lowercase hexadecimal strings drawn by Word.Hex,
+// with lengths in [minLen, maxLen] growing with the size like SliceOf.
+// Strings shrink to shorter ones drawn from the same randomness.
+func Hex(minLen, maxLen int) Generator[string] {
+	return sizedString(minLen, maxLen, func(r *randomizer.Rand, n int) string { return r.Word().Hex(n, false) })
+}
+
+// Decimal returns a generator of digit strings drawn by Word.Decimal, with
+// lengths and shrinking as for Hex.
+func Decimal(minLen, maxLen int) Generator[string] {
+	return sizedString(minLen, maxLen, func(r *randomizer.Rand, n int) string { return r.Word().Decimal(n) })
+}
+
+func sizedString(minLen, maxLen int, gen func(r *randomizer.Rand, n int) string) Generator[string] {
+	minLen = max(minLen, 0)
+	maxLen = max(maxLen, minLen)
+	length := Generator[int]{run: func(r *randomizer.Rand, size int) tree[int] {
+		hi := minLen + (maxLen-minLen)*size/MaxSize
+		return intTree(minLen+r.IntN(hi-minLen+1), minLen)
+	}}
+	return Bind(length, func(n int) Generator[string] {
+		return FromRand(func(r *randomizer.Rand) string { return gen(r, n) })
+	})
+}
+
+// Pattern returns a generator of strings drawn by Word.Pattern from mask.
+// The values do not shrink.
+func Pattern(mask string) Generator[string] {
+	return FromRand(func(r *randomizer.Rand) string { return r.Word().Pattern(mask) })
+}
+
+// Regex returns a generator of strings matching pattern, drawn by
+// Word.FromRegex. The values do not shrink. It panics if pattern is rejected
+// by Word.CompileRegex.
+func Regex(pattern string) Generator[string] {
+	if _, err := randomizer.Word.CompileRegex(pattern, randomizer.DefaultRegexMaxRepeat); err != nil {
+		panic("proptest: " + err.Error())
+	}
+	return FromRand(func(r *randomizer.Rand) string {
+		s, _ := r.Word().FromRegex(pattern)
+		return s
+	})
+}
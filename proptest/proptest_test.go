@@ -0,0 +1,215 @@
+package proptest_test
+
+import (
+	"fmt"
+	"net/netip"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/colduction/randomizer"
+	"github.com/colduction/randomizer/proptest"
+)
+
+// recorder captures the failure reported by Check.
+type recorder struct {
+	testing.TB
+	failed bool
+	msg    string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Fatalf(format string, args ...any) {
+	r.failed = true
+	r.msg = fmt.Sprintf(format, args...)
+}
+
+func mustFail[T any](t *testing.T, gen proptest.Generator[T], property func(T) bool) string {
+	t.Helper()
+	rec := &recorder{TB: t}
+	proptest.CheckWith(rec, &proptest.Config{Seed: 12345, Runs: 200}, gen, property)
+	if !rec.failed {
+		t.Fatal("property unexpectedly held")
+	}
+	if !strings.Contains(rec.msg, "seed 0x3039") {
+		t.Fatalf("report lacks the seed: %s", rec.msg)
+	}
+	return rec.msg
+}
+
+func minimal(msg string) string {
+	_, after, _ := strings.Cut(msg, "minimal input:  ")
+	before, _, _ := strings.Cut(after, "\n")
+	return before
+}
+
+func TestCheckPasses(t *testing.T) {
+	proptest.Check(t, proptest.Int(-100, 100), func(x int) bool { return x*x >= 0 })
+	proptest.Check(t, proptest.SliceOf(proptest.Int(0, 9), 0, 20), func(s []int) bool {
+		return len(slices.Sorted(slices.Values(s))) == len(s)
+	})
+}
+
+func TestShrinkInt(t *testing.T) {
+	msg := mustFail(t, proptest.Int(-1000, 1000), func(x int) bool { return x < 37 })
+	if got := minimal(msg); got != "37" {
+		t.Fatalf("minimal input %s, want 37\n%s", got, msg)
+	}
+	msg = mustFail(t, proptest.Int[uint8](10, 200), func(x uint8) bool { return x < 37 })
+	if got := minimal(msg); got != "0x25" {
+		t.Fatalf("minimal input %s, want 0x25\n%s", got, msg)
+	}
+}
+
+func TestShrinkString(t *testing.T) {
+	msg := mustFail(t, proptest.String(30), func(s string) bool { return len(s) < 5 })
+	if got := minimal(msg); got != `"aaaaa"` {
+		t.Fatalf("minimal input %s, want \"aaaaa\"\n%s", got, msg)
+	}
+}
+
+func TestShrinkSlice(t *testing.T) {
+	gen := proptest.SliceOf(proptest.Int(0, 1000), 0, 50)
+	msg := mustFail(t, gen, func(s []int) bool {
+		for _, v := range s {
+			if v >= 500 {
+				return false
+			}
+		}
+		return true
+	})
+	if got := minimal(msg); got != "[]int{500}" {
+		t.Fatalf("minimal input %s, want []int{500}\n%s", got, msg)
+	}
+}
+
+func TestShrinkAddr(t *testing.T) {
+	prefix := netip.MustParsePrefix("128.0.0.0/1")
+	msg := mustFail(t, proptest.Addr4(), func(a netip.Addr) bool { return !prefix.Contains(a) })
+	if got := minimal(msg); got != "128.0.0.0" {
+		t.Fatalf("minimal input %s, want 128.0.0.0\n%s", got, msg)
+	}
+	msg = mustFail(t, proptest.Addr6(), func(a netip.Addr) bool { return a.As16()[15] == 0 })
+	if got := minimal(msg); got != "::1" {
+		t.Fatalf("minimal input %s, want ::1\n%s", got, msg)
+	}
+}
+
+func TestShrinkThroughCombinators(t *testing.T) {
+	even := proptest.Filter(proptest.Int(0, 10000), func(x int) bool { return x%2 == 0 })
+	msg := mustFail(t, proptest.Map(even, func(x int) string { return fmt.Sprint(x) }), func(s string) bool { return len(s) < 3 })
+	// Halving steps can stop at any small even three-digit number.
+	if got := minimal(msg); len(got) != 5 || strings.IndexAny(got[3:4], "02468") < 0 {
+		t.Fatalf("minimal input %s, want an even three-digit number\n%s", got, msg)
+	}
+
+	// A length followed by a slice of that length: both shrink.
+	sized := proptest.Bind(proptest.Int(1, 20), func(n int) proptest.Generator[[]int] {
+		return proptest.SliceOf(proptest.Int(0, 100), n, n)
+	})
+	msg = mustFail(t, sized, func(s []int) bool { return len(s) < 3 })
+	if got := minimal(msg); got != "[]int{0, 0, 0}" {
+		t.Fatalf("minimal input %s, want []int{0, 0, 0}\n%s", got, msg)
+	}
+
+	choice := proptest.OneOf(proptest.Just("a"), proptest.StringOf("xyz", 1, 5))
+	msg = mustFail(t, choice, func(s string) bool { return s == "a" })
+	if got := minimal(msg); got != `"x"` {
+		t.Fatalf("minimal input %s, want \"x\"\n%s", got, msg)
+	}
+
+	maps := proptest.MapOf(proptest.StringOf(proptest.Digits, 1, 3), proptest.Bool(), 10)
+	msg = mustFail(t, maps, func(m map[string]bool) bool { return len(m) < 2 })
+	if got := minimal(msg); got != `map[string]bool{"0":false, "1":false}` {
+		t.Fatalf("minimal input %s\n%s", got, msg)
+	}
+}
+
+func TestCheckPanicsAndFilters(t *testing.T) {
+	msg := mustFail(t, proptest.Int(0, 100), func(x int) bool {
+		if x > 10 {
+			panic("boom")
+		}
+		return true
+	})
+	if got := minimal(msg); got != "11" {
+		t.Fatalf("minimal input %s, want 11\n%s", got, msg)
+	}
+	if !strings.Contains(msg, "panic: boom") || !strings.Contains(msg, "proptest_test.go") {
+		t.Fatalf("report lacks the panic value and stack\n%s", msg)
+	}
+
+	never := proptest.Filter(proptest.Int(0, 10), func(int) bool { return false })
+	msg = mustFail(t, never, func(int) bool { return true })
+	if !strings.Contains(msg, "filter rejected") {
+		t.Fatalf("unexpected report %s", msg)
+	}
+}
+
+func TestSampleReproducible(t *testing.T) {
+	gen := proptest.SliceOf(proptest.Float64(-1, 1), 0, 10)
+	a, b := proptest.Sample(gen, 99, 20), proptest.Sample(gen, 99, 20)
+	if fmt.Sprint(a) != fmt.Sprint(b) {
+		t.Fatal("samples with equal seeds differ")
+	}
+	for _, s := range a {
+		for _, v := range s {
+			if v < -1 || v >= 1 {
+				t.Fatalf("Float64(-1, 1) produced %v", v)
+			}
+		}
+	}
+}
+
+func TestWrappedGenerators(t *testing.T) {
+	hex := regexp.MustCompile(`^[0-9a-f]{3}$`)
+	msg := mustFail(t, proptest.Hex(0, 40), func(s string) bool { return len(s) < 3 })
+	if got, _ := strconv.Unquote(minimal(msg)); !hex.MatchString(got) {
+		t.Fatalf("minimal input %s, want three hex digits\n%s", minimal(msg), msg)
+	}
+
+	prefix := netip.MustParsePrefix("10.1.0.0/16")
+	msg = mustFail(t, proptest.AddrInPrefix(prefix), func(a netip.Addr) bool { return a.As4()[3] == 0 })
+	if got := minimal(msg); got != "10.1.0.1" {
+		t.Fatalf("minimal input %s, want 10.1.0.1\n%s", got, msg)
+	}
+	proptest.Check(t, proptest.AddrInPrefix(netip.MustParsePrefix("2001:db8::5/64")), func(a netip.Addr) bool {
+		return netip.MustParsePrefix("2001:db8::/64").Contains(a)
+	})
+
+	code := regexp.MustCompile(`^[A-Z]{2}-\d{3}$`)
+	proptest.Check(t, proptest.Regex(`[A-Z]{2}-\d{3}`), code.MatchString)
+	proptest.Check(t, proptest.Pattern("??-###"), func(s string) bool { return len(s) == 6 && s[2] == '-' })
+	proptest.Check(t, proptest.Decimal(1, 5), func(s string) bool {
+		_, err := strconv.Atoi(s)
+		return err == nil && len(s) <= 5
+	})
+	proptest.Check(t, proptest.Domain(&randomizer.DomainOptions{TLD: "test"}), func(s string) bool {
+		return strings.HasSuffix(s, ".test")
+	})
+	proptest.Check(t, proptest.Email(nil), func(s string) bool { return strings.Contains(s, "@") })
+	proptest.Check(t, proptest.Addr4Category(randomizer.IPv4Private), func(a netip.Addr) bool { return a.IsPrivate() })
+	proptest.Check(t, proptest.Addr6Unicast(randomizer.LinkLocalType), netip.Addr.IsLinkLocalUnicast)
+
+	gen := proptest.FromRand(func(r *randomizer.Rand) string { return r.Word().IBAN("DE") })
+	if a, b := proptest.Sample(gen, 7, 5), proptest.Sample(gen, 7, 5); !slices.Equal(a, b) {
+		t.Fatalf("FromRand samples with equal seeds differ: %q, %q", a, b)
+	}
+	for name, f := range map[string]func(){
+		"AddrInPrefix": func() { proptest.AddrInPrefix(netip.Prefix{}) },
+		"Regex":        func() { proptest.Regex(`[`) },
+		"Domain":       func() { proptest.Domain(&randomizer.DomainOptions{TLD: "-bad"}) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s with invalid input did not panic", name)
+				}
+			}()
+			f()
+		}()
+	}
+}
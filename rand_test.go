@@ -0,0 +1,108 @@
+package randomizer_test
+
+import (
+	"bytes"
+	"math/rand/v2"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/colduction/randomizer"
+)
+
+func TestRandDeterministic(t *testing.T) {
+	a, b := randomizer.NewRand(42), randomizer.NewRand(42)
+	if a.Seed() != 42 {
+		t.Fatalf("Seed() = %d, want 42", a.Seed())
+	}
+	for range 100 {
+		if a.Uint64() != b.Uint64() || a.Uint64n(1000) != b.Uint64n(1000) || a.Float64() != b.Float64() {
+			t.Fatal("Rands with equal seeds diverged")
+		}
+	}
+	pa, pb := make([]byte, 37), make([]byte, 37)
+	a.Read(pa)
+	b.Read(pb)
+	if !bytes.Equal(pa, pb) {
+		t.Fatal("Read diverged")
+	}
+	if randomizer.NewRand(1).Uint64() == randomizer.NewRand(2).Uint64() {
+		t.Fatal("different seeds produced the same value")
+	}
+}
+
+func TestRandRanges(t *testing.T) {
+	r := randomizer.NewRand(7)
+	for range 10000 {
+		if v := r.Uint64n(10); v >= 10 {
+			t.Fatalf("Uint64n(10) = %d", v)
+		}
+		if v := r.IntN(3); v < 0 || v >= 3 {
+			t.Fatalf("IntN(3) = %d", v)
+		}
+		if v := r.Float64(); v < 0 || v >= 1 {
+			t.Fatalf("Float64() = %v", v)
+		}
+	}
+	if r.IntN(0) != 0 || r.IntN(-1) != 0 || r.Uint64n(0) != 0 {
+		t.Fatal("empty ranges should yield 0")
+	}
+}
+
+func TestRandSource(t *testing.T) {
+	var _ rand.Source = randomizer.NewRand(0)
+	a, b := rand.New(randomizer.NewRand(9)), rand.New(randomizer.NewRand(9))
+	if a.Perm(20)[7] != b.Perm(20)[7] {
+		t.Fatal("math/rand/v2 over equal seeds diverged")
+	}
+}
+
+func TestRandRegex(t *testing.T) {
+	draw := func(r *randomizer.Rand) []string {
+		// The inner \b makes compilation draw candidates as well.
+		re, err := r.Word().CompileRegex(`[a-z]+\b[ .][A-Z]\d{3}`, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		out := make([]string, 5)
+		for i := range out {
+			out[i] = re.Generate()
+		}
+		return out
+	}
+	if a, b := draw(randomizer.NewRand(7)), draw(randomizer.NewRand(7)); !slices.Equal(a, b) {
+		t.Fatalf("regexes compiled from equal seeds diverged: %q, %q", a, b)
+	}
+}
+
+func TestRandGenerators(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	draw := func(r *randomizer.Rand) []any {
+		bt, err := r.BusinessTime(start, start.AddDate(0, 1, 0), time.UTC)
+		if err != nil {
+			t.Fatal(err)
+		}
+		p, err := r.Geo().PointInRadius(randomizer.LatLng{Lat: 10, Lng: 20}, 1000)
+		if err != nil {
+			t.Fatal(err)
+		}
+		re, err := r.Word().FromRegex(`[a-z]{4}\d+`)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return []any{
+			r.Word().Hex(16, false), r.Word().ISBN13(), r.Word().Pattern("###-???"), re,
+			r.Network().Addr6(), r.Network().URL(nil), r.Network().Email(nil),
+			r.Geo().Point(), p,
+			r.TimeInterval(start, start.AddDate(1, 0, 0)), bt,
+			r.DurationInterval(time.Second, time.Hour), r.ExpDuration(time.Minute),
+		}
+	}
+	a, b := draw(randomizer.NewRand(5)), draw(randomizer.NewRand(5))
+	if !slices.Equal(a, b) {
+		t.Fatalf("generators over equal seeds diverged:\n%v\n%v", a, b)
+	}
+	if c := draw(randomizer.NewRand(6)); slices.Equal(a, c) {
+		t.Fatal("different seeds produced the same values")
+	}
+}
@@ -0,0 +1,102 @@
+// Package randtest provides test helpers for the randomizer package: seeded
+// generators whose seed is logged when a test fails, and fuzz seed inputs. It
+// registers the -randomizer.seed flag, so it is meant to be imported from
+// tests only.
+package randtest
+
+import (
+	"flag"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/colduction/randomizer"
+)
+
+// SeedEnv is the environment variable read by ForTest. When set, its value is
+// parsed as an unsigned integer (0x-prefixed hex is accepted) and used as the
+// seed instead of a random one.
+const SeedEnv = "RANDOMIZER_SEED"
+
+// seedFlag is the -randomizer.seed flag. It takes precedence over SeedEnv;
+// setting it to the empty string clears it.
+var seedFlag = new(seedValue)
+
+func init() {
+	flag.Var(seedFlag, "randomizer.seed", "seed for randtest.ForTest generators; empty for a random seed")
+}
+
+type seedValue struct {
+	seed uint64
+	set  bool
+}
+
+func (v *seedValue) String() string {
+	if v == nil || !v.set {
+		return ""
+	}
+	return "0x" + strconv.FormatUint(v.seed, 16)
+}
+
+func (v *seedValue) Set(s string) error {
+	if s == "" {
+		*v = seedValue{}
+		return nil
+	}
+	seed, err := strconv.ParseUint(s, 0, 64)
+	if err != nil {
+		return err
+	}
+	*v = seedValue{seed: seed, set: true}
+	return nil
+}
+
+// ForTest returns a Rand for use in t. The seed comes from the
+// -randomizer.seed flag, else the RANDOMIZER_SEED environment variable, else
+// it is random. If t fails, the seed is logged when t finishes so the run can
+// be replayed with
+//
+//	go test -run 'TestName' -randomizer.seed=0x...
+//
+// Only values drawn from the returned Rand replay, including those of its
+// Word, Network and Geo generators, its time methods, its FuzzArgs and
+// FillWith given it as FillOptions.Rand; the package-level generators keep
+// drawing from randomizer.DefaultHashPool.
+// A malformed RANDOMIZER_SEED fails t immediately.
+func ForTest(t testing.TB) *randomizer.Rand {
+	t.Helper()
+	seed, err := testSeed()
+	if err != nil {
+		t.Fatalf("randtest: invalid %s: %v", SeedEnv, err)
+	}
+	t.Cleanup(func() {
+		if t.Failed() {
+			t.Logf("randtest: %s used seed %#x; replay with -randomizer.seed=%#x or %s=%#x",
+				t.Name(), seed, seed, SeedEnv, seed)
+		}
+	})
+	return randomizer.NewRand(seed)
+}
+
+func testSeed() (uint64, error) {
+	if seedFlag.set {
+		return seedFlag.seed, nil
+	}
+	if s := os.Getenv(SeedEnv); s != "" {
+		return strconv.ParseUint(s, 0, 64)
+	}
+	return randomizer.Uint[uint64](), nil
+}
+
+// AddFuzzSeeds adds n seed inputs to f, each made of one value per argument.
+// The arguments must match the parameters of the fuzz target after
+// *testing.T. They are drawn from a Rand seeded as ForTest seeds one, so the
+// same -randomizer.seed or RANDOMIZER_SEED reproduces the seed corpus for
+// arguments from FuzzArgFor and FuzzArgFrom.
+func AddFuzzSeeds(f *testing.F, n int, args ...randomizer.FuzzArg) {
+	f.Helper()
+	r := ForTest(f)
+	for range n {
+		f.Add(r.FuzzArgs(args...)...)
+	}
+}
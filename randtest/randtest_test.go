@@ -0,0 +1,132 @@
+package randtest_test
+
+import (
+	"flag"
+	"fmt"
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/colduction/randomizer"
+	"github.com/colduction/randomizer/randtest"
+)
+
+// fakeTB records what ForTest does with a test.
+type fakeTB struct {
+	testing.TB
+	failed   bool
+	logs     []string
+	cleanups []func()
+}
+
+func (f *fakeTB) Helper()           {}
+func (f *fakeTB) Name() string      { return "TestFake" }
+func (f *fakeTB) Failed() bool      { return f.failed }
+func (f *fakeTB) Cleanup(fn func()) { f.cleanups = append(f.cleanups, fn) }
+
+func (f *fakeTB) Logf(format string, args ...any) {
+	f.logs = append(f.logs, fmt.Sprintf(format, args...))
+}
+
+func (f *fakeTB) Fatalf(format string, args ...any) {
+	f.failed = true
+	f.Logf(format, args...)
+}
+
+func (f *fakeTB) finish() {
+	for i := len(f.cleanups) - 1; i >= 0; i-- {
+		f.cleanups[i]()
+	}
+}
+
+// seedFlag clears the -randomizer.seed flag for the duration of t, so the
+// tests behave the same when the package is run with it set.
+func seedFlag(t *testing.T) flag.Value {
+	t.Helper()
+	f := flag.Lookup("randomizer.seed")
+	if f == nil {
+		t.Fatal("-randomizer.seed flag is not registered")
+	}
+	prev := f.Value.String()
+	t.Cleanup(func() { f.Value.Set(prev) })
+	if err := f.Value.Set(""); err != nil {
+		t.Fatal(err)
+	}
+	return f.Value
+}
+
+func TestForTestLogsSeedOnlyOnFailure(t *testing.T) {
+	seedFlag(t)
+	t.Setenv(randtest.SeedEnv, "")
+
+	passed := &fakeTB{TB: t}
+	randtest.ForTest(passed)
+	passed.finish()
+	if len(passed.logs) != 0 {
+		t.Fatalf("passing test logged %q", passed.logs)
+	}
+
+	failed := &fakeTB{TB: t}
+	r := randtest.ForTest(failed)
+	failed.failed = true
+	failed.finish()
+	want := fmt.Sprintf("-randomizer.seed=%#x", r.Seed())
+	if len(failed.logs) != 1 || !strings.Contains(failed.logs[0], want) || !strings.Contains(failed.logs[0], "TestFake") {
+		t.Fatalf("failing test logged %q, want a line containing %s", failed.logs, want)
+	}
+}
+
+func TestForTestEnvOverride(t *testing.T) {
+	seedFlag(t)
+	t.Setenv(randtest.SeedEnv, "0x2a")
+	a, b := randtest.ForTest(t), randtest.ForTest(t)
+	if a.Seed() != 42 || b.Seed() != 42 {
+		t.Fatalf("seeds = %#x, %#x, want 0x2a", a.Seed(), b.Seed())
+	}
+	if a.Uint64() != randomizer.NewRand(42).Uint64() {
+		t.Fatal("ForTest generator differs from NewRand with the same seed")
+	}
+
+	t.Setenv(randtest.SeedEnv, "bogus")
+	bad := &fakeTB{TB: t}
+	randtest.ForTest(bad)
+	if !bad.failed || !strings.Contains(bad.logs[0], randtest.SeedEnv) {
+		t.Fatalf("malformed seed not reported: %q", bad.logs)
+	}
+}
+
+func TestForTestFlagOverride(t *testing.T) {
+	f := seedFlag(t)
+	t.Setenv(randtest.SeedEnv, "1")
+	if err := f.Set("7"); err != nil {
+		t.Fatal(err)
+	}
+	if got := randtest.ForTest(t).Seed(); got != 7 {
+		t.Fatalf("seed = %d, want 7 from the flag", got)
+	}
+	if err := f.Set(""); err != nil {
+		t.Fatal(err)
+	}
+	if got := randtest.ForTest(t).Seed(); got != 1 {
+		t.Fatalf("seed = %d, want 1 from the environment after clearing the flag", got)
+	}
+	if err := f.Set("x"); err == nil {
+		t.Fatal("Set accepted a malformed seed")
+	}
+}
+
+func FuzzAddFuzzSeeds(f *testing.F) {
+	randtest.AddFuzzSeeds(f, 16,
+		randomizer.FuzzArgFrom(func(r *randomizer.Rand) string { return r.Network().Addr4().String() }),
+		randomizer.FuzzArgFor[int](),
+	)
+	f.Fuzz(func(t *testing.T, s string, _ int) {
+		addr, err := netip.ParseAddr(s)
+		if err != nil || !addr.Is4() {
+			return
+		}
+		if addr.String() != s {
+			t.Fatalf("%q round-tripped to %q", s, addr)
+		}
+	})
+}
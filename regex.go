@@ -0,0 +1,348 @@
+package randomizer
+
+import (
+	"errors"
+	"regexp"
+	"regexp/syntax"
+	"slices"
+	"unicode"
+	"unicode/utf8"
+)
+
+// DefaultRegexMaxRepeat is the upper bound used for unbounded repetitions
+// (*, + and {n,}) when a Regex is compiled without an explicit limit.
+const DefaultRegexMaxRepeat = 10
+
+const (
+	// printable ASCII range used for "." and for broad (mostly negated) classes.
+	asciiPrintableLo rune = 0x20
+	asciiPrintableHi rune = 0x7E
+	// classes covering at least this many runes are narrowed to printable ASCII
+	// when they intersect it, so that [^a] yields readable text.
+	broadClassRunes uint64 = 0x10000
+)
+
+// ErrRegexNoMatch is returned by CompileRegex for a pattern that matches no
+// string, such as [^\x00-\x{10FFFF}] or a^b.
+var ErrRegexNoMatch = errors.New("randomizer: regular expression matches no string")
+
+// regexCheckSamples is the number of candidates CompileRegex draws to show
+// that a pattern with inner anchors or word boundaries can be satisfied.
+const regexCheckSamples = 1000
+
+type regexOp uint8
+
+const (
+	regexEmpty regexOp = iota
+	regexLiteral
+	regexClass
+	regexConcat
+	regexAlternate
+	regexRepeat
+)
+
+// regexNode is a precompiled form of a syntax.Regexp tree.
+type regexNode struct {
+	op    regexOp
+	runes []rune       // literal runes, or class ranges as lo,hi pairs
+	folds [][]rune     // case-folding orbits of literal runes, nil if not folded
+	cum   []uint64     // cumulative class sizes used for uniform selection
+	total uint64       // number of runes in a class
+	subs  []*regexNode // children for concat, alternate and repeat
+	min   int          // minimum repetitions
+	max   int          // maximum repetitions
+}
+
+// Regex is a compiled regular expression that generates random matching strings.
+// A Regex is immutable once compiled. One compiled by Word is safe for
+// concurrent use; one compiled by the Word of a Rand draws from that Rand and
+// is not.
+type Regex struct {
+	root      *regexNode
+	maxRepeat int
+	src       *Rand // nil draws from DefaultHashPool
+	// check is the anchored pattern that candidates are matched against when
+	// the pattern has anchors or word boundaries that generation cannot
+	// guarantee; nil otherwise.
+	check *regexp.Regexp
+}
+
+// CompileRegex parses pattern using Perl syntax and returns a reusable Regex
+// generator. Unbounded repetitions are limited to maxRepeat extra occurrences;
+// if maxRepeat is not positive, DefaultRegexMaxRepeat is used.
+//
+// To keep the output readable, "." and classes of at least 65536 code points,
+// such as \pL or [^a], generate only their printable ASCII members when they
+// have any; a narrower class such as \p{Greek} is used in full.
+//
+// Anchors and word boundaries other than a leading ^ or trailing $ are
+// enforced by drawing candidates until one matches. CompileRegex returns
+// ErrRegexNoMatch if the pattern matches no string, or if none of its first
+// candidates satisfy such anchors.
+func (w word) CompileRegex(pattern string, maxRepeat int) (*Regex, error) {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return nil, err
+	}
+	if maxRepeat <= 0 {
+		maxRepeat = DefaultRegexMaxRepeat
+	}
+	root := compileRegexNode(re, maxRepeat)
+	if root == nil {
+		return nil, ErrRegexNoMatch
+	}
+	rx := &Regex{root: root, maxRepeat: maxRepeat, src: w.r}
+	if hasInnerAssertion(re) {
+		if rx.check, err = regexp.Compile(`\A(?:` + pattern + `)\z`); err != nil {
+			return nil, err
+		}
+		if !rx.satisfiable(w.r.source()) {
+			return nil, ErrRegexNoMatch
+		}
+	}
+	return rx, nil
+}
+
+// satisfiable reports whether any of regexCheckSamples candidates matches.
+func (r *Regex) satisfiable(rng *wordRNG) bool {
+	for range regexCheckSamples {
+		if r.check.Match(r.root.appendTo(nil, rng)) {
+			return true
+		}
+	}
+	return false
+}
+
+// FromRegex generates a random string matching the regular expression pattern.
+// It is a shorthand for CompileRegex with DefaultRegexMaxRepeat followed by Generate.
+func (w word) FromRegex(pattern string) (string, error) {
+	re, err := w.CompileRegex(pattern, DefaultRegexMaxRepeat)
+	if err != nil {
+		return "", err
+	}
+	return re.Generate(), nil
+}
+
+// MaxRepeat returns the upper bound applied to unbounded repetitions.
+func (r *Regex) MaxRepeat() int {
+	return r.maxRepeat
+}
+
+// Generate returns a random string matching the compiled expression.
+func (r *Regex) Generate() string {
+	return string(r.GenerateBytes())
+}
+
+// GenerateBytes returns a random byte slice matching the compiled expression.
+func (r *Regex) GenerateBytes() []byte {
+	rng := r.src.source()
+	for {
+		out := r.root.appendTo(nil, rng)
+		if r.check == nil || r.check.Match(out) {
+			return out
+		}
+	}
+}
+
+// isAssertion reports whether op is an anchor or word boundary.
+func isAssertion(op syntax.Op) bool {
+	switch op {
+	case syntax.OpBeginLine, syntax.OpEndLine, syntax.OpBeginText, syntax.OpEndText,
+		syntax.OpWordBoundary, syntax.OpNoWordBoundary:
+		return true
+	}
+	return false
+}
+
+// hasInnerAssertion reports whether re has an anchor or word boundary other
+// than leading ^ or \A and trailing $ or \z, which every generated string
+// satisfies.
+func hasInnerAssertion(re *syntax.Regexp) bool {
+	subs := []*syntax.Regexp{re}
+	if re.Op == syntax.OpConcat {
+		subs = re.Sub
+		for len(subs) > 0 && (subs[0].Op == syntax.OpBeginLine || subs[0].Op == syntax.OpBeginText) {
+			subs = subs[1:]
+		}
+		for len(subs) > 0 && (subs[len(subs)-1].Op == syntax.OpEndLine || subs[len(subs)-1].Op == syntax.OpEndText) {
+			subs = subs[:len(subs)-1]
+		}
+	} else if re.Op == syntax.OpBeginLine || re.Op == syntax.OpBeginText || re.Op == syntax.OpEndLine || re.Op == syntax.OpEndText {
+		return false
+	}
+	var walk func(*syntax.Regexp) bool
+	walk = func(re *syntax.Regexp) bool {
+		return isAssertion(re.Op) || slices.ContainsFunc(re.Sub, walk)
+	}
+	return slices.ContainsFunc(subs, walk)
+}
+
+// compileRegexNode returns the node generating re, or nil if re matches no
+// string.
+func compileRegexNode(re *syntax.Regexp, maxRepeat int) *regexNode {
+	switch re.Op {
+	case syntax.OpLiteral:
+		n := &regexNode{op: regexLiteral, runes: re.Rune}
+		if re.Flags&syntax.FoldCase != 0 {
+			n.folds = make([][]rune, len(re.Rune))
+			for i, r := range re.Rune {
+				n.folds[i] = foldOrbit(r)
+			}
+		}
+		return n
+	case syntax.OpCharClass:
+		n := newClassNode(re.Rune)
+		if n.total == 0 {
+			return nil
+		}
+		return n
+	case syntax.OpNoMatch:
+		return nil
+	case syntax.OpAnyCharNotNL, syntax.OpAnyChar:
+		return newClassNode([]rune{asciiPrintableLo, asciiPrintableHi})
+	case syntax.OpCapture:
+		return compileRegexNode(re.Sub[0], maxRepeat)
+	case syntax.OpStar:
+		return newRepeatNode(re.Sub[0], 0, maxRepeat, maxRepeat)
+	case syntax.OpPlus:
+		return newRepeatNode(re.Sub[0], 1, 1+maxRepeat, maxRepeat)
+	case syntax.OpQuest:
+		return newRepeatNode(re.Sub[0], 0, 1, maxRepeat)
+	case syntax.OpRepeat:
+		max := re.Max
+		if max < 0 {
+			max = re.Min + maxRepeat
+		}
+		return newRepeatNode(re.Sub[0], re.Min, max, maxRepeat)
+	case syntax.OpConcat, syntax.OpAlternate:
+		n := &regexNode{op: regexConcat, subs: make([]*regexNode, 0, len(re.Sub))}
+		if re.Op == syntax.OpAlternate {
+			n.op = regexAlternate
+		}
+		for _, sub := range re.Sub {
+			c := compileRegexNode(sub, maxRepeat)
+			if c == nil {
+				if n.op == regexConcat {
+					return nil
+				}
+				continue // an alternative that matches nothing is never chosen
+			}
+			n.subs = append(n.subs, c)
+		}
+		if len(n.subs) == 0 {
+			return nil
+		}
+		return n
+	default:
+		// Empty matches, anchors and word boundaries consume no input.
+		return &regexNode{op: regexEmpty}
+	}
+}
+
+// newRepeatNode returns a node repeating sub. A sub that matches nothing can
+// only be repeated zero times.
+func newRepeatNode(sub *syntax.Regexp, min, max, maxRepeat int) *regexNode {
+	n := compileRegexNode(sub, maxRepeat)
+	if n == nil {
+		if min > 0 {
+			return nil
+		}
+		return &regexNode{op: regexEmpty}
+	}
+	return &regexNode{op: regexRepeat, subs: []*regexNode{n}, min: min, max: max}
+}
+
+// newClassNode builds a class node from lo,hi rune pairs, dropping surrogates
+// and narrowing broad classes to printable ASCII where possible.
+func newClassNode(ranges []rune) *regexNode {
+	ranges = excludeSurrogates(ranges)
+	if classSize(ranges) >= broadClassRunes {
+		if ascii := intersectRanges(ranges, asciiPrintableLo, asciiPrintableHi); len(ascii) > 0 {
+			ranges = ascii
+		}
+	}
+	n := &regexNode{op: regexClass, runes: ranges, cum: make([]uint64, 0, len(ranges)/2)}
+	for i := 0; i+1 < len(ranges); i += 2 {
+		n.total += uint64(ranges[i+1]-ranges[i]) + 1
+		n.cum = append(n.cum, n.total)
+	}
+	return n
+}
+
+func classSize(ranges []rune) uint64 {
+	var total uint64
+	for i := 0; i+1 < len(ranges); i += 2 {
+		total += uint64(ranges[i+1]-ranges[i]) + 1
+	}
+	return total
+}
+
+func intersectRanges(ranges []rune, lo, hi rune) []rune {
+	var out []rune
+	for i := 0; i+1 < len(ranges); i += 2 {
+		a, b := max(ranges[i], lo), min(ranges[i+1], hi)
+		if a <= b {
+			out = append(out, a, b)
+		}
+	}
+	return out
+}
+
+func excludeSurrogates(ranges []rune) []rune {
+	const surrLo, surrHi rune = 0xD800, 0xDFFF
+	out := make([]rune, 0, len(ranges))
+	for i := 0; i+1 < len(ranges); i += 2 {
+		lo, hi := ranges[i], ranges[i+1]
+		if hi < surrLo || lo > surrHi {
+			out = append(out, lo, hi)
+			continue
+		}
+		if lo < surrLo {
+			out = append(out, lo, surrLo-1)
+		}
+		if hi > surrHi {
+			out = append(out, surrHi+1, hi)
+		}
+	}
+	return out
+}
+
+// foldOrbit returns every rune equivalent to r under simple case folding.
+func foldOrbit(r rune) []rune {
+	orbit := []rune{r}
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		orbit = append(orbit, f)
+	}
+	return orbit
+}
+
+func (n *regexNode) appendTo(out []byte, rng *wordRNG) []byte {
+	switch n.op {
+	case regexLiteral:
+		for i, r := range n.runes {
+			if n.folds != nil && len(n.folds[i]) > 1 {
+				r = n.folds[i][uniformUint64n(uint64(len(n.folds[i])), rng)]
+			}
+			out = utf8.AppendRune(out, r)
+		}
+	case regexClass:
+		if n.total == 0 {
+			return out
+		}
+		v := uniformUint64n(n.total, rng)
+		i, _ := slices.BinarySearch(n.cum, v+1)
+		return utf8.AppendRune(out, n.runes[2*i+1]-rune(n.cum[i]-1-v))
+	case regexConcat:
+		for _, sub := range n.subs {
+			out = sub.appendTo(out, rng)
+		}
+	case regexAlternate:
+		out = n.subs[uniformUint64n(uint64(len(n.subs)), rng)].appendTo(out, rng)
+	case regexRepeat:
+		count := n.min + int(uniformUint64n(uint64(n.max-n.min+1), rng))
+		for range count {
+			out = n.subs[0].appendTo(out, rng)
+		}
+	}
+	return out
+}
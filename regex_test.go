@@ -0,0 +1,111 @@
+package randomizer_test
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/colduction/randomizer"
+)
+
+func TestRegexGenerateMatches(t *testing.T) {
+	patterns := []string{
+		`[a-z]{3,8}@[a-z]+\.(com|net|org)`,
+		`^\d{3}-\d{2}-\d{4}$`,
+		`[^a-z]+`,
+		`(?i)hello world`,
+		`\p{Greek}{5}`,
+		`[\x{4e00}-\x{9fff}]+`,
+		`a*b+c?d{2,}`,
+		`.{0,16}`,
+		`\bfoo\b|bar`,
+		`(?:ab|cd|)*`,
+	}
+	for _, p := range patterns {
+		re, err := randomizer.Word.CompileRegex(p, 0)
+		if err != nil {
+			t.Fatalf("CompileRegex(%q) error: %v", p, err)
+		}
+		check := regexp.MustCompile(`^(?:` + p + `)$`)
+		for range 200 {
+			s := re.Generate()
+			if !utf8.ValidString(s) {
+				t.Fatalf("pattern %q generated invalid UTF-8: %q", p, s)
+			}
+			if !check.MatchString(s) {
+				t.Fatalf("pattern %q generated non-matching string %q", p, s)
+			}
+		}
+	}
+}
+
+func TestRegexMaxRepeat(t *testing.T) {
+	re, err := randomizer.Word.CompileRegex(`x*`, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if re.MaxRepeat() != 3 {
+		t.Fatalf("MaxRepeat = %d, want 3", re.MaxRepeat())
+	}
+	for range 1000 {
+		if s := re.Generate(); len(s) > 3 {
+			t.Fatalf("x* with max repeat 3 generated %q", s)
+		}
+	}
+}
+
+func TestRegexFromRegexInvalid(t *testing.T) {
+	if _, err := randomizer.Word.FromRegex(`(`); err == nil {
+		t.Fatal("FromRegex with invalid pattern should return error")
+	}
+	s, err := randomizer.Word.FromRegex(`[0-9a-f]{32}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s) != 32 {
+		t.Fatalf("FromRegex length = %d, want 32", len(s))
+	}
+}
+
+func BenchmarkRegexGenerate(b *testing.B) {
+	re, err := randomizer.Word.CompileRegex(`[A-Z]{2}\d{6}-[a-z0-9]{8}`, 0)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	for b.Loop() {
+		benchWordString = re.Generate()
+	}
+}
+
+func TestRegexNoMatch(t *testing.T) {
+	for _, p := range []string{
+		`[^\x00-\x{10FFFF}]`,
+		`a^b`,
+		`x[^\x00-\x{10FFFF}]y`,
+		`a\bb`,
+		`(?:a$)b`,
+	} {
+		if _, err := randomizer.Word.CompileRegex(p, 0); !errors.Is(err, randomizer.ErrRegexNoMatch) {
+			t.Errorf("CompileRegex(%q) error = %v, want ErrRegexNoMatch", p, err)
+		}
+	}
+	for _, p := range []string{
+		`ab|[^\x00-\x{10FFFF}]`,
+		`x[^\x00-\x{10FFFF}]*y`,
+		`(?m)a$\n^b`,
+		`(?:^|-)\d+`,
+	} {
+		re, err := randomizer.Word.CompileRegex(p, 0)
+		if err != nil {
+			t.Fatalf("CompileRegex(%q) error: %v", p, err)
+		}
+		check := regexp.MustCompile(`\A(?:` + p + `)\z`)
+		for range 200 {
+			if s := re.Generate(); !check.MatchString(s) {
+				t.Fatalf("pattern %q generated non-matching string %q", p, s)
+			}
+		}
+	}
+}
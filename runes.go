@@ -0,0 +1,294 @@
+package randomizer
+
+import (
+	"slices"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// RuneUnit selects how the length passed to Word.Runes is measured.
+type RuneUnit uint8
+
+const (
+	// RuneCount measures length in code points; each invalid byte counts as one.
+	RuneCount RuneUnit = iota
+	// ByteCount bounds the encoded length in bytes. The output may be shorter
+	// than requested when no remaining candidate fits.
+	ByteCount
+	// GraphemeCount measures length in user-perceived characters, where a base
+	// rune together with its combining marks and joiners is one grapheme.
+	// Runes that merge by themselves (such as Hangul jamo or regional
+	// indicators drawn from the caller's tables) are still counted separately.
+	GraphemeCount
+)
+
+// RuneOptions configures Word.Runes. The zero value produces plain text
+// measured in runes.
+type RuneOptions struct {
+	// Unit selects how length is measured.
+	Unit RuneUnit
+	// CombiningMarks attaches combining diacritical marks to some base runes.
+	CombiningMarks bool
+	// ZeroWidth inserts zero-width spaces, word joiners, byte order marks and
+	// zero-width (non-)joiners.
+	ZeroWidth bool
+	// RTL mixes in Hebrew and Arabic runes and bidirectional marks.
+	RTL bool
+	// InvalidUTF8 inserts bytes that are never valid in UTF-8.
+	InvalidUTF8 bool
+}
+
+// defaultRuneTables are used by Word.Runes when no table is given.
+var defaultRuneTables = []*unicode.RangeTable{unicode.L, unicode.N, unicode.P, unicode.S}
+
+var (
+	// combiningMarks is the Combining Diacritical Marks block.
+	combiningMarks = newRuneUnion([]*unicode.RangeTable{{R16: []unicode.Range16{{Lo: 0x0300, Hi: 0x036F, Stride: 1}}}})
+	rtlRunes       = newRuneUnion([]*unicode.RangeTable{unicode.Hebrew, unicode.Arabic})
+)
+
+var (
+	// zeroWidthStandalone form their own grapheme: ZWSP, WORD JOINER and BOM.
+	zeroWidthStandalone = [...]rune{'\u200B', '\u2060', '\uFEFF'}
+	// zeroWidthJoiners extend the preceding grapheme: ZWNJ and ZWJ.
+	zeroWidthJoiners = [...]rune{'\u200C', '\u200D'}
+	// bidiMarks are RIGHT-TO-LEFT MARK and LEFT-TO-RIGHT MARK.
+	bidiMarks = [...]rune{'\u200F', '\u200E'}
+	// invalidUTF8Bytes are invalid on their own and never start a sequence.
+	invalidUTF8Bytes = [...]byte{0x80, 0xBF, 0xC0, 0xC1, 0xF5, 0xFE, 0xFF}
+)
+
+type runeRange struct {
+	lo, hi, stride uint32
+}
+
+// runeSet allows uniform selection over the code points of a RangeTable.
+type runeSet struct {
+	ranges []runeRange
+	cum    []uint64
+	total  uint64
+}
+
+// runeSets caches a *runeSet per table exported by package unicode. Other
+// tables are not cached, since a caller building a table for every call would
+// otherwise grow the cache without bound.
+var runeSets sync.Map
+
+// stdRuneTables returns the set of tables exported by package unicode.
+var stdRuneTables = sync.OnceValue(func() map[*unicode.RangeTable]bool {
+	std := make(map[*unicode.RangeTable]bool)
+	for _, m := range []map[string]*unicode.RangeTable{
+		unicode.Categories, unicode.Scripts, unicode.Properties,
+		unicode.FoldCategory, unicode.FoldScript,
+	} {
+		for _, t := range m {
+			std[t] = true
+		}
+	}
+	for _, t := range unicode.GraphicRanges {
+		std[t] = true
+	}
+	for _, t := range unicode.PrintRanges {
+		std[t] = true
+	}
+	return std
+})
+
+func loadRuneSet(t *unicode.RangeTable) *runeSet {
+	if s, ok := runeSets.Load(t); ok {
+		return s.(*runeSet)
+	}
+	s := new(runeSet)
+	add := func(lo, hi, stride uint32) {
+		s.ranges = append(s.ranges, runeRange{lo, hi, stride})
+		s.total += uint64((hi-lo)/stride) + 1
+		s.cum = append(s.cum, s.total)
+	}
+	// Surrogates cannot be encoded in UTF-8, so they are left out.
+	const surrLo, surrHi uint32 = 0xD800, 0xDFFF
+	addValid := func(lo, hi, stride uint32) {
+		if hi < surrLo || lo > surrHi {
+			add(lo, hi, stride)
+			return
+		}
+		if lo < surrLo {
+			add(lo, lo+(surrLo-1-lo)/stride*stride, stride)
+		}
+		if hi > surrHi {
+			if first := lo + (surrHi+1-lo+stride-1)/stride*stride; first <= hi {
+				add(first, hi, stride)
+			}
+		}
+	}
+	for _, r := range t.R16 {
+		addValid(uint32(r.Lo), uint32(r.Hi), uint32(r.Stride))
+	}
+	for _, r := range t.R32 {
+		addValid(r.Lo, r.Hi, r.Stride)
+	}
+	if !stdRuneTables()[t] {
+		return s
+	}
+	actual, _ := runeSets.LoadOrStore(t, s)
+	return actual.(*runeSet)
+}
+
+// at returns the v-th code point of the set.
+func (s *runeSet) at(v uint64) rune {
+	i, _ := slices.BinarySearch(s.cum, v+1)
+	r := s.ranges[i]
+	return rune(r.hi - uint32(s.cum[i]-1-v)*r.stride)
+}
+
+// runeUnion selects uniformly across several tables. Code points present in
+// more than one table are proportionally more likely.
+type runeUnion struct {
+	sets  []*runeSet
+	total uint64
+}
+
+func newRuneUnion(tables []*unicode.RangeTable) runeUnion {
+	u := runeUnion{sets: make([]*runeSet, 0, len(tables))}
+	for _, t := range tables {
+		if t == nil {
+			continue
+		}
+		s := loadRuneSet(t)
+		u.sets = append(u.sets, s)
+		u.total += s.total
+	}
+	return u
+}
+
+func (u *runeUnion) pick(rng *wordRNG) rune {
+	v := uniformUint64n(u.total, rng)
+	for _, s := range u.sets {
+		if v < s.total {
+			return s.at(v)
+		}
+		v -= s.total
+	}
+	return utf8.RuneError
+}
+
+// runeBudget tracks the remaining length in the configured unit.
+type runeBudget struct {
+	unit RuneUnit
+	left int
+}
+
+// take consumes the cost of an element of the given size, reporting false
+// without consuming anything if it does not fit.
+func (b *runeBudget) take(bytes int, grapheme bool) bool {
+	cost := 1
+	switch b.unit {
+	case ByteCount:
+		cost = bytes
+	case GraphemeCount:
+		if !grapheme {
+			cost = 0
+		}
+	}
+	if cost > b.left {
+		return false
+	}
+	b.left -= cost
+	return true
+}
+
+func appendRunes(out []byte, length int, u *runeUnion, opts *RuneOptions, rng *wordRNG) []byte {
+	// Consecutive misses tolerated in ByteCount mode before giving up.
+	const maxMisses = 8
+
+	b := runeBudget{unit: opts.Unit, left: length}
+	misses := 0
+	for b.left > 0 && misses < maxMisses {
+		roll := uniformUint64n(16, rng)
+		switch {
+		case opts.InvalidUTF8 && roll == 0:
+			if b.take(1, true) {
+				out = append(out, invalidUTF8Bytes[uniformUint64n(uint64(len(invalidUTF8Bytes)), rng)])
+				misses = 0
+				continue
+			}
+		case opts.ZeroWidth && roll == 1:
+			r := zeroWidthStandalone[uniformUint64n(uint64(len(zeroWidthStandalone)), rng)]
+			if b.take(utf8.RuneLen(r), true) {
+				out = utf8.AppendRune(out, r)
+				misses = 0
+				continue
+			}
+		case opts.RTL && roll == 2:
+			r := bidiMarks[uniformUint64n(uint64(len(bidiMarks)), rng)]
+			if b.take(utf8.RuneLen(r), true) {
+				out = utf8.AppendRune(out, r)
+				misses = 0
+				continue
+			}
+		default:
+			src := u
+			if opts.RTL && roll >= 12 {
+				src = &rtlRunes
+			}
+			r := src.pick(rng)
+			if b.take(utf8.RuneLen(r), true) {
+				out = utf8.AppendRune(out, r)
+				out = appendExtenders(out, &b, opts, rng)
+				misses = 0
+				continue
+			}
+		}
+		if b.unit == ByteCount {
+			misses++
+		}
+	}
+	return out
+}
+
+// appendExtenders attaches combining marks and joiners to the preceding base rune.
+func appendExtenders(out []byte, b *runeBudget, opts *RuneOptions, rng *wordRNG) []byte {
+	if opts.CombiningMarks && uniformUint64n(4, rng) == 0 {
+		for n := 1 + uniformUint64n(3, rng); n > 0; n-- {
+			r := combiningMarks.pick(rng)
+			if !b.take(utf8.RuneLen(r), false) {
+				return out
+			}
+			out = utf8.AppendRune(out, r)
+		}
+	}
+	if opts.ZeroWidth && uniformUint64n(8, rng) == 0 {
+		r := zeroWidthJoiners[uniformUint64n(uint64(len(zeroWidthJoiners)), rng)]
+		if b.take(utf8.RuneLen(r), false) {
+			out = utf8.AppendRune(out, r)
+		}
+	}
+	return out
+}
+
+// Runes generates random text of the specified length with code points drawn
+// uniformly from ranges, or from letters, numbers, punctuation and symbols if
+// ranges is empty. A nil opts measures length in runes and produces valid UTF-8.
+func (w word) Runes(length int, ranges []*unicode.RangeTable, opts *RuneOptions) string {
+	return string(w.RunesBytes(length, ranges, opts))
+}
+
+// RunesBytes generates a random byte slice of the specified length using the
+// same rules as Runes.
+func (w word) RunesBytes(length int, ranges []*unicode.RangeTable, opts *RuneOptions) []byte {
+	if length <= 0 {
+		return nil
+	}
+	if len(ranges) == 0 {
+		ranges = defaultRuneTables
+	}
+	u := newRuneUnion(ranges)
+	if u.total == 0 {
+		return nil
+	}
+	if opts == nil {
+		opts = new(RuneOptions)
+	}
+	rng := w.r.source()
+	return appendRunes(make([]byte, 0, length), length, &u, opts, rng)
+}
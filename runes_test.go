@@ -0,0 +1,108 @@
+package randomizer_test
+
+import (
+	"strings"
+	"testing"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/colduction/randomizer"
+)
+
+func TestWordRunesTables(t *testing.T) {
+	tables := []*unicode.RangeTable{unicode.Han, unicode.Cyrillic}
+	for range 100 {
+		s := randomizer.Word.Runes(64, tables, nil)
+		if !utf8.ValidString(s) {
+			t.Fatalf("Runes produced invalid UTF-8: %q", s)
+		}
+		if n := utf8.RuneCountInString(s); n != 64 {
+			t.Fatalf("Runes rune count = %d, want 64", n)
+		}
+		for _, r := range s {
+			if !unicode.In(r, tables...) {
+				t.Fatalf("Runes produced %U outside requested tables", r)
+			}
+		}
+	}
+	if got := randomizer.Word.Runes(0, nil, nil); got != "" {
+		t.Fatalf("Runes(0) = %q, want empty string", got)
+	}
+	if got := randomizer.Word.RunesBytes(0, nil, nil); got != nil {
+		t.Fatalf("RunesBytes(0) = %v, want nil", got)
+	}
+}
+
+func TestWordRunesUnits(t *testing.T) {
+	opts := &randomizer.RuneOptions{CombiningMarks: true, ZeroWidth: true, RTL: true}
+	for range 200 {
+		opts.Unit = randomizer.RuneCount
+		if n := utf8.RuneCountInString(randomizer.Word.Runes(50, nil, opts)); n != 50 {
+			t.Fatalf("RuneCount length = %d, want 50", n)
+		}
+		opts.Unit = randomizer.ByteCount
+		b := randomizer.Word.RunesBytes(50, []*unicode.RangeTable{unicode.Han}, opts)
+		if len(b) > 50 || !utf8.Valid(b) {
+			t.Fatalf("ByteCount produced %d bytes (valid=%t), want at most 50", len(b), utf8.Valid(b))
+		}
+	}
+
+	opts = &randomizer.RuneOptions{Unit: randomizer.GraphemeCount, CombiningMarks: true}
+	latin := []*unicode.RangeTable{{R16: []unicode.Range16{{Lo: 'a', Hi: 'z', Stride: 1}}}}
+	for range 200 {
+		s := randomizer.Word.Runes(20, latin, opts)
+		bases := 0
+		for _, r := range s {
+			if !unicode.Is(unicode.Mn, r) {
+				bases++
+			}
+		}
+		if bases != 20 {
+			t.Fatalf("GraphemeCount produced %d base runes in %q, want 20", bases, s)
+		}
+	}
+}
+
+func TestWordRunesInvalidUTF8(t *testing.T) {
+	opts := &randomizer.RuneOptions{InvalidUTF8: true}
+	invalid := false
+	for range 100 {
+		b := randomizer.Word.RunesBytes(64, nil, opts)
+		if utf8.RuneCount(b) != 64 {
+			t.Fatalf("InvalidUTF8 rune count = %d, want 64", utf8.RuneCount(b))
+		}
+		invalid = invalid || !utf8.Valid(b)
+	}
+	if !invalid {
+		t.Fatal("InvalidUTF8 never produced invalid UTF-8")
+	}
+}
+
+func BenchmarkWordRunes(b *testing.B) {
+	tables := []*unicode.RangeTable{unicode.Han}
+	b.ReportAllocs()
+	for b.Loop() {
+		benchWordString = randomizer.Word.Runes(64, tables, nil)
+	}
+}
+
+func TestWordRunesSkipsSurrogates(t *testing.T) {
+	if s := randomizer.Word.Runes(8, []*unicode.RangeTable{unicode.Cs}, nil); s != "" {
+		t.Fatalf("Runes over surrogates only = %q, want empty", s)
+	}
+	mixed := []*unicode.RangeTable{
+		unicode.Cs,
+		{R16: []unicode.Range16{{Lo: 0xD7F0, Hi: 0xE00F, Stride: 1}, {Lo: 'a', Hi: 'z', Stride: 5}}},
+	}
+	for _, unit := range []randomizer.RuneUnit{randomizer.RuneCount, randomizer.ByteCount} {
+		for range 200 {
+			s := randomizer.Word.Runes(30, mixed, &randomizer.RuneOptions{Unit: unit})
+			if !utf8.ValidString(s) || strings.ContainsRune(s, utf8.RuneError) {
+				t.Fatalf("unit %d: Runes produced %q", unit, s)
+			}
+			if unit == randomizer.ByteCount && len(s) > 30 {
+				t.Fatalf("ByteCount output is %d bytes, want at most 30", len(s))
+			}
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package randomizer
+
+import (
+	"errors"
+	"net/netip"
+	"slices"
+)
+
+// ErrNoSpecialPurposeBlock is returned when no registry block matches a filter.
+var ErrNoSpecialPurposeBlock = errors.New("randomizer: no matching special-purpose block")
+
+// SpecialPurposeBlock is an entry of the IANA IPv4 or IPv6 special-purpose
+// address registry. Attributes listed as N/A in the registry are false.
+type SpecialPurposeBlock struct {
+	Prefix             netip.Prefix
+	Name               string
+	RFC                string
+	Source             bool
+	Destination        bool
+	Forwardable        bool
+	GloballyReachable  bool
+	ReservedByProtocol bool
+}
+
+// Registry attribute flags used to build specialPurposeBlocks.
+const (
+	spSrc uint8 = 1 << iota
+	spDst
+	spFwd
+	spGlobal
+	spReserved
+)
+
+func spb(prefix, name, rfc string, attrs uint8) SpecialPurposeBlock {
+	return SpecialPurposeBlock{
+		Prefix:             netip.MustParsePrefix(prefix),
+		Name:               name,
+		RFC:                rfc,
+		Source:             attrs&spSrc != 0,
+		Destination:        attrs&spDst != 0,
+		Forwardable:        attrs&spFwd != 0,
+		GloballyReachable:  attrs&spGlobal != 0,
+		ReservedByProtocol: attrs&spReserved != 0,
+	}
+}
+
+// ref: https://www.iana.org/assignments/iana-ipv4-special-registry
+// ref: https://www.iana.org/assignments/iana-ipv6-special-registry
+var specialPurposeBlocks = []SpecialPurposeBlock{
+	spb("0.0.0.0/8", "This network", "RFC 791", spSrc|spReserved),
+	spb("0.0.0.0/32", "This host on this network", "RFC 1122", spSrc|spReserved),
+	spb("10.0.0.0/8", "Private-Use", "RFC 1918", spSrc|spDst|spFwd),
+	spb("100.64.0.0/10", "Shared Address Space", "RFC 6598", spSrc|spDst|spFwd),
+	spb("127.0.0.0/8", "Loopback", "RFC 1122", spReserved),
+	spb("169.254.0.0/16", "Link Local", "RFC 3927", spSrc|spDst|spReserved),
+	spb("172.16.0.0/12", "Private-Use", "RFC 1918", spSrc|spDst|spFwd),
+	spb("192.0.0.0/24", "IETF Protocol Assignments", "RFC 6890", 0),
+	spb("192.0.0.0/29", "IPv4 Service Continuity Prefix", "RFC 7335", spSrc|spDst|spFwd),
+	spb("192.0.0.8/32", "IPv4 dummy address", "RFC 7600", spSrc),
+	spb("192.0.0.9/32", "Port Control Protocol Anycast", "RFC 7723", spSrc|spDst|spFwd|spGlobal),
+	spb("192.0.0.10/32", "Traversal Using Relays around NAT Anycast", "RFC 8155", spSrc|spDst|spFwd|spGlobal),
+	spb("192.0.0.170/32", "NAT64/DNS64 Discovery", "RFC 8880", spReserved),
+	spb("192.0.0.171/32", "NAT64/DNS64 Discovery", "RFC 8880", spReserved),
+	spb("192.0.2.0/24", "Documentation (TEST-NET-1)", "RFC 5737", 0),
+	spb("192.31.196.0/24", "AS112-v4", "RFC 7535", spSrc|spDst|spFwd|spGlobal),
+	spb("192.52.193.0/24", "AMT", "RFC 7450", spSrc|spDst|spFwd|spGlobal),
+	spb("192.88.99.0/24", "Deprecated (6to4 Relay Anycast)", "RFC 7526", 0),
+	spb("192.168.0.0/16", "Private-Use", "RFC 1918", spSrc|spDst|spFwd),
+	spb("192.175.48.0/24", "Direct Delegation AS112 Service", "RFC 7534", spSrc|spDst|spFwd|spGlobal),
+	spb("198.18.0.0/15", "Benchmarking", "RFC 2544", spSrc|spDst|spFwd),
+	spb("198.51.100.0/24", "Documentation (TEST-NET-2)", "RFC 5737", 0),
+	spb("203.0.113.0/24", "Documentation (TEST-NET-3)", "RFC 5737", 0),
+	spb("240.0.0.0/4", "Reserved", "RFC 1112", spReserved),
+	spb("255.255.255.255/32", "Limited Broadcast", "RFC 919", spDst|spReserved),
+
+	spb("::1/128", "Loopback Address", "RFC 4291", spReserved),
+	spb("::/128", "Unspecified Address", "RFC 4291", spSrc|spReserved),
+	spb("::ffff:0:0/96", "IPv4-mapped Address", "RFC 4291", spReserved),
+	spb("64:ff9b::/96", "IPv4-IPv6 Translat.", "RFC 6052", spSrc|spDst|spFwd|spGlobal),
+	spb("64:ff9b:1::/48", "IPv4-IPv6 Translat.", "RFC 8215", spSrc|spDst|spFwd),
+	spb("100::/64", "Discard-Only Address Block", "RFC 6666", spSrc|spDst|spFwd),
+	spb("100:0:0:1::/64", "Dummy IPv6 Prefix", "RFC 9780", spSrc),
+	spb("2001::/23", "IETF Protocol Assignments", "RFC 2928", 0),
+	spb("2001::/32", "TEREDO", "RFC 4380", spSrc|spDst|spFwd),
+	spb("2001:1::1/128", "Port Control Protocol Anycast", "RFC 7723", spSrc|spDst|spFwd|spGlobal),
+	spb("2001:1::2/128", "Traversal Using Relays around NAT Anycast", "RFC 8155", spSrc|spDst|spFwd|spGlobal),
+	spb("2001:1::3/128", "DNS-SD Service Registration Protocol Anycast", "RFC 9665", spSrc|spDst|spFwd|spGlobal),
+	spb("2001:2::/48", "Benchmarking", "RFC 5180", spSrc|spDst|spFwd),
+	spb("2001:3::/32", "AMT", "RFC 7450", spSrc|spDst|spFwd|spGlobal),
+	spb("2001:4:112::/48", "AS112-v6", "RFC 7535", spSrc|spDst|spFwd|spGlobal),
+	spb("2001:10::/28", "Deprecated (previously ORCHID)", "RFC 4843", 0),
+	spb("2001:20::/28", "ORCHIDv2", "RFC 7343", spSrc|spDst|spFwd|spGlobal),
+	spb("2001:30::/28", "Drone Remote ID Protocol Entity Tags (DETs) Prefix", "RFC 9374", spSrc|spDst|spFwd|spGlobal),
+	spb("2001:db8::/32", "Documentation", "RFC 3849", 0),
+	spb("2002::/16", "6to4", "RFC 3056", spSrc|spDst|spFwd),
+	spb("2620:4f:8000::/48", "Direct Delegation AS112 Service", "RFC 7534", spSrc|spDst|spFwd|spGlobal),
+	spb("3fff::/20", "Documentation", "RFC 9637", 0),
+	spb("5f00::/16", "Segment Routing (SRv6) SIDs", "RFC 9602", spSrc|spDst|spFwd),
+	spb("fc00::/7", "Unique-Local", "RFC 4193", spSrc|spDst|spFwd),
+	spb("fe80::/10", "Link-Local Unicast", "RFC 4291", spSrc|spDst|spReserved),
+}
+
+// specialPurposePrefixes returns the registry prefixes of the given family.
+func specialPurposePrefixes(is4 bool) []netip.Prefix {
+	var out []netip.Prefix
+	for _, b := range specialPurposeBlocks {
+		if b.Prefix.Addr().Is4() == is4 {
+			out = append(out, b.Prefix)
+		}
+	}
+	return out
+}
+
+var (
+	ipv4SpecialPurpose = specialPurposePrefixes(true)
+	ipv6SpecialPurpose = specialPurposePrefixes(false)
+)
+
+// SpecialPurposeBlocks returns a copy of the embedded IANA IPv4 and IPv6
+// special-purpose address registries, IPv4 entries first.
+func (network) SpecialPurposeBlocks() []SpecialPurposeBlock {
+	return slices.Clone(specialPurposeBlocks)
+}
+
+// SpecialPurposeAddr generates a random address inside a registry block for
+// which match returns true, or inside any block if match is nil. The block is
+// chosen uniformly among the matches, then the address uniformly within it.
+func (n network) SpecialPurposeAddr(match func(SpecialPurposeBlock) bool) (netip.Addr, error) {
+	var matches []netip.Prefix
+	for _, b := range specialPurposeBlocks {
+		if match == nil || match(b) {
+			matches = append(matches, b.Prefix)
+		}
+	}
+	if len(matches) == 0 {
+		return netip.Addr{}, ErrNoSpecialPurposeBlock
+	}
+	rng := n.r.source()
+	return addrInPrefix(matches[uniformUint64n(uint64(len(matches)), rng)], nil, rng)
+}
+
+// AddrClass describes what an address is, as reported by Network.Classify.
+type AddrClass struct {
+	// Special lists the registry blocks containing the address, most specific first.
+	Special []SpecialPurposeBlock
+	// IPv4 is the category of an IPv4 address, or zero if it has none.
+	IPv4 IPv4Category
+	// Unicast is the type of an IPv6 unicast address, or zero if it has none.
+	Unicast UnicastType
+	// Scope is the scope of a multicast address, or zero for unicast.
+	Scope MulticastScope
+}
+
+// ipv4ScopeOrder lists IPv4 multicast scopes from the most to the least specific range.
+var ipv4ScopeOrder = [...]MulticastScope{LinkLocalScope, SiteLocalScope, OrgLocalScope, AdminLocalScope, GlobalScope}
+
+// Classify reports the special-purpose registry blocks, IPv4 category, IPv6
+// unicast type and multicast scope of a. It is meant for asserting what kind
+// of address a generator produced.
+func (network) Classify(a netip.Addr) AddrClass {
+	var c AddrClass
+	if !a.IsValid() {
+		return c
+	}
+	a = a.WithZone("")
+	for _, b := range specialPurposeBlocks {
+		if b.Prefix.Contains(a) {
+			c.Special = append(c.Special, b)
+		}
+	}
+	slices.SortStableFunc(c.Special, func(x, y SpecialPurposeBlock) int {
+		return y.Prefix.Bits() - x.Prefix.Bits()
+	})
+
+	if a.Is4() {
+		for cat := IPv4Public; int(cat) < len(ipv4Categories); cat++ {
+			if ipv4Categories[cat].contains(a) {
+				c.IPv4 = cat
+				break
+			}
+		}
+		if c.IPv4 == IPv4Multicast {
+			for _, scope := range ipv4ScopeOrder {
+				if space := ipv4MulticastScopes[scope]; space.contains(a) {
+					c.Scope = scope
+					break
+				}
+			}
+		}
+		return c
+	}
+	if a.IsMulticast() {
+		c.Scope = MulticastScope(a.As16()[1] & 0x0F)
+		return c
+	}
+	for t := GlobalType; int(t) < len(ipv6UnicastPrefixes); t++ {
+		if ipv6UnicastPrefixes[t].Contains(a) {
+			c.Unicast = t
+			break
+		}
+	}
+	return c
+}
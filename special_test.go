@@ -0,0 +1,95 @@
+package randomizer_test
+
+import (
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/colduction/randomizer"
+)
+
+func TestNetworkSpecialPurposeBlocks(t *testing.T) {
+	blocks := randomizer.Network.SpecialPurposeBlocks()
+	if len(blocks) == 0 {
+		t.Fatal("SpecialPurposeBlocks returned no entries")
+	}
+	blocks[0].Name = "mutated"
+	if randomizer.Network.SpecialPurposeBlocks()[0].Name == "mutated" {
+		t.Fatal("SpecialPurposeBlocks did not return a copy")
+	}
+	for _, b := range blocks {
+		if !b.Prefix.IsValid() || b.Prefix.Masked() != b.Prefix {
+			t.Fatalf("registry entry %q has non-canonical prefix %v", b.Name, b.Prefix)
+		}
+	}
+}
+
+func TestNetworkAddr6UnicastInExcludeSpecial(t *testing.T) {
+	opts := &randomizer.PrefixOptions{ExcludeSpecialPurpose: true}
+	for range 10000 {
+		a, err := randomizer.Network.Addr6UnicastIn(randomizer.GlobalType, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c := randomizer.Network.Classify(a)
+		if c.Unicast != randomizer.GlobalType || len(c.Special) != 0 {
+			t.Fatalf("Addr6UnicastIn(GlobalType) returned %v classified as %+v", a, c)
+		}
+	}
+	a, err := randomizer.Network.Addr6UnicastIn(randomizer.UniqueLocalType, nil)
+	if err != nil || a.As16()[0] != 0xFD {
+		t.Fatalf("Addr6UnicastIn(UniqueLocalType) = %v, %v", a, err)
+	}
+}
+
+func TestNetworkSpecialPurposeAddr(t *testing.T) {
+	isDoc := func(b randomizer.SpecialPurposeBlock) bool {
+		return strings.HasPrefix(b.Name, "Documentation") && b.Prefix.Addr().Is6()
+	}
+	for range 1000 {
+		a, err := randomizer.Network.SpecialPurposeAddr(isDoc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c := randomizer.Network.Classify(a)
+		if len(c.Special) == 0 || !isDoc(c.Special[0]) {
+			t.Fatalf("SpecialPurposeAddr returned %v classified as %+v", a, c)
+		}
+	}
+	_, err := randomizer.Network.SpecialPurposeAddr(func(randomizer.SpecialPurposeBlock) bool { return false })
+	if err != randomizer.ErrNoSpecialPurposeBlock {
+		t.Fatalf("SpecialPurposeAddr with no match error = %v, want ErrNoSpecialPurposeBlock", err)
+	}
+}
+
+func TestNetworkClassify(t *testing.T) {
+	cases := []struct {
+		addr    string
+		special string
+		ipv4    randomizer.IPv4Category
+		unicast randomizer.UnicastType
+		scope   randomizer.MulticastScope
+	}{
+		{"10.1.2.3", "Private-Use", randomizer.IPv4Private, 0, 0},
+		{"192.0.0.9", "Port Control Protocol Anycast", 0, 0, 0},
+		{"8.8.8.8", "", randomizer.IPv4Public, 0, 0},
+		{"239.255.0.1", "", randomizer.IPv4Multicast, 0, randomizer.SiteLocalScope},
+		{"2001:db8::1", "Documentation", 0, randomizer.GlobalType, 0},
+		{"2001:0:4136:e378::1", "TEREDO", 0, randomizer.GlobalType, 0},
+		{"2606:4700::1111", "", 0, randomizer.GlobalType, 0},
+		{"fe80::1", "Link-Local Unicast", 0, randomizer.LinkLocalType, 0},
+		{"fd12::1", "Unique-Local", 0, randomizer.UniqueLocalType, 0},
+		{"ff05::2", "", 0, 0, randomizer.SiteLocalScope},
+	}
+	for _, tc := range cases {
+		c := randomizer.Network.Classify(netip.MustParseAddr(tc.addr))
+		special := ""
+		if len(c.Special) > 0 {
+			special = c.Special[0].Name
+		}
+		if special != tc.special || c.IPv4 != tc.ipv4 || c.Unicast != tc.unicast || c.Scope != tc.scope {
+			t.Fatalf("Classify(%s) = {%q %d %d %#x}, want {%q %d %d %#x}",
+				tc.addr, special, c.IPv4, c.Unicast, c.Scope, tc.special, tc.ipv4, tc.unicast, tc.scope)
+		}
+	}
+}
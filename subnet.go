@@ -0,0 +1,111 @@
+package randomizer
+
+import (
+	"errors"
+	"net/netip"
+	"slices"
+)
+
+// ErrInvalidSubnetBits is returned when a subnet length is shorter than its
+// parent prefix or longer than the address.
+var ErrInvalidSubnetBits = errors.New("randomizer: invalid subnet prefix length")
+
+// childPrefix returns a random aligned child of length bits inside parent.
+func childPrefix(parent netip.Prefix, bits int, rng *wordRNG) netip.Prefix {
+	width := prefixWidth(parent)
+	offset := randomBits128(bits-parent.Bits(), rng).shl(width - bits)
+	base := u128FromAddr(parent.Addr()).or(offset)
+	return netip.PrefixFrom(base.addr(parent.Addr().Is4()), bits)
+}
+
+// Subnet generates a random child prefix of the specified length inside
+// parent, chosen uniformly among all aligned children.
+func (n network) Subnet(parent netip.Prefix, bits int) (netip.Prefix, error) {
+	if !parent.IsValid() {
+		return netip.Prefix{}, ErrInvalidPrefix
+	}
+	parent = parent.Masked()
+	if bits < parent.Bits() || bits > prefixWidth(parent) {
+		return netip.Prefix{}, ErrInvalidSubnetBits
+	}
+	rng := n.r.source()
+	return childPrefix(parent, bits, rng), nil
+}
+
+// splitAround returns the blocks left over in block after carving child out
+// of it: one sibling per prefix length from block.Bits()+1 to child.Bits().
+func splitAround(block, child netip.Prefix) []netip.Prefix {
+	width := prefixWidth(block)
+	is4 := block.Addr().Is4()
+	c := u128FromAddr(child.Addr())
+	out := make([]netip.Prefix, 0, child.Bits()-block.Bits())
+	for bits := block.Bits() + 1; bits <= child.Bits(); bits++ {
+		sibling := c.xor(uint128{lo: 1}.shl(width - bits)).and(lowMask128(width - bits).xor(lowMask128(width)))
+		out = append(out, netip.PrefixFrom(sibling.addr(is4), bits))
+	}
+	return out
+}
+
+// CarveSubnets carves non-overlapping random subnets out of parent, one for
+// each prefix length in sizes, and returns them sorted by address. Larger
+// subnets are placed first, buddy-allocator style, so carving only fails with
+// ErrPrefixExhausted when the sizes add up to more than the parent holds.
+// Every placement is uniform among the aligned positions still free.
+func (n network) CarveSubnets(parent netip.Prefix, sizes []int) ([]netip.Prefix, error) {
+	if !parent.IsValid() {
+		return nil, ErrInvalidPrefix
+	}
+	parent = parent.Masked()
+	for _, bits := range sizes {
+		if bits < parent.Bits() || bits > prefixWidth(parent) {
+			return nil, ErrInvalidSubnetBits
+		}
+	}
+	order := slices.Clone(sizes)
+	slices.Sort(order)
+
+	rng := n.r.source()
+	free := []netip.Prefix{parent}
+	out := make([]netip.Prefix, 0, len(sizes))
+	for _, bits := range order {
+		// Weigh each free block by its size relative to the smallest
+		// candidate so that every aligned position is equally likely.
+		smallest := -1
+		for _, b := range free {
+			if b.Bits() <= bits {
+				smallest = max(smallest, b.Bits())
+			}
+		}
+		if smallest < 0 {
+			return nil, ErrPrefixExhausted
+		}
+		var total uint128
+		for _, b := range free {
+			if b.Bits() <= bits {
+				total = total.add(uint128{lo: 1}.shl(smallest - b.Bits()))
+			}
+		}
+		v := uniformUint128n(total, rng)
+		for i, b := range free {
+			if b.Bits() > bits {
+				continue
+			}
+			w := uint128{lo: 1}.shl(smallest - b.Bits())
+			if v.cmp(w) >= 0 {
+				v = v.sub(w)
+				continue
+			}
+			child := childPrefix(b, bits, rng)
+			out = append(out, child)
+			free = append(slices.Delete(free, i, i+1), splitAround(b, child)...)
+			break
+		}
+	}
+	slices.SortFunc(out, func(a, b netip.Prefix) int {
+		if c := a.Addr().Compare(b.Addr()); c != 0 {
+			return c
+		}
+		return a.Bits() - b.Bits()
+	})
+	return out, nil
+}
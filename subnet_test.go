@@ -0,0 +1,78 @@
+package randomizer_test
+
+import (
+	"net/netip"
+	"slices"
+	"testing"
+
+	"github.com/colduction/randomizer"
+)
+
+func TestNetworkSubnet(t *testing.T) {
+	cases := []struct {
+		parent string
+		bits   int
+	}{
+		{"10.0.0.0/8", 24}, {"10.0.0.0/8", 8}, {"0.0.0.0/0", 32},
+		{"2001:db8::/32", 64}, {"::/0", 128}, {"::/0", 1},
+	}
+	for _, tc := range cases {
+		parent := netip.MustParsePrefix(tc.parent)
+		for range 500 {
+			p, err := randomizer.Network.Subnet(parent, tc.bits)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if p.Bits() != tc.bits || p.Masked() != p || !parent.Contains(p.Addr()) {
+				t.Fatalf("Subnet(%s, %d) = %v", tc.parent, tc.bits, p)
+			}
+		}
+	}
+	if _, err := randomizer.Network.Subnet(netip.MustParsePrefix("10.0.0.0/16"), 8); err != randomizer.ErrInvalidSubnetBits {
+		t.Fatalf("Subnet shorter than parent error = %v, want ErrInvalidSubnetBits", err)
+	}
+}
+
+func TestNetworkCarveSubnets(t *testing.T) {
+	parent := netip.MustParsePrefix("10.0.0.0/16")
+	// 2×/18 + 4×/20 + 16×/24 + 32×/25 = 32768+16384+4096+4096 of 65536 addresses.
+	var sizes []int
+	for _, s := range []struct{ bits, n int }{{18, 2}, {20, 4}, {24, 16}, {25, 32}} {
+		for range s.n {
+			sizes = append(sizes, s.bits)
+		}
+	}
+	for range 50 {
+		subnets, err := randomizer.Network.CarveSubnets(parent, sizes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(subnets) != len(sizes) {
+			t.Fatalf("CarveSubnets returned %d subnets, want %d", len(subnets), len(sizes))
+		}
+		if !slices.IsSortedFunc(subnets, func(a, b netip.Prefix) int { return a.Addr().Compare(b.Addr()) }) {
+			t.Fatalf("CarveSubnets result is not sorted: %v", subnets)
+		}
+		for i, p := range subnets {
+			if !parent.Contains(p.Addr()) || p.Masked() != p {
+				t.Fatalf("CarveSubnets returned %v outside %v", p, parent)
+			}
+			for _, q := range subnets[i+1:] {
+				if p.Overlaps(q) {
+					t.Fatalf("CarveSubnets returned overlapping %v and %v", p, q)
+				}
+			}
+		}
+	}
+
+	full := []int{17, 17}
+	if _, err := randomizer.Network.CarveSubnets(parent, full); err != nil {
+		t.Fatalf("CarveSubnets filling the parent exactly error: %v", err)
+	}
+	if _, err := randomizer.Network.CarveSubnets(parent, append(full, 32)); err != randomizer.ErrPrefixExhausted {
+		t.Fatalf("CarveSubnets beyond capacity error = %v, want ErrPrefixExhausted", err)
+	}
+	if _, err := randomizer.Network.CarveSubnets(netip.MustParsePrefix("::/0"), []int{128, 1, 64}); err != nil {
+		t.Fatalf("CarveSubnets on ::/0 error: %v", err)
+	}
+}
@@ -0,0 +1,241 @@
+package randomizer
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+var (
+	// ErrInvalidTimeRange is returned when the end of a time range is not
+	// after its start.
+	ErrInvalidTimeRange = errors.New("randomizer: time range end must be after start")
+	// ErrInvalidTimeOptions is returned when TimeOptions are inconsistent.
+	ErrInvalidTimeOptions = errors.New("randomizer: invalid time options")
+	// ErrNoMatchingTime is returned when no instant in a range satisfies the
+	// weekday and time-of-day restrictions.
+	ErrNoMatchingTime = errors.New("randomizer: no time in range matches the options")
+)
+
+// TimeOptions restricts the times generated by TimeIntervalWith.
+type TimeOptions struct {
+	// Granularity is the step between candidate times, counted from the start
+	// of the range or, with a time-of-day window, from the window opening.
+	// Zero means one nanosecond.
+	Granularity time.Duration
+	// Location is the zone in which Weekdays and the window are interpreted
+	// and in which the result is returned; nil means the location of start.
+	Location *time.Location
+	// Weekdays lists the allowed days; empty allows every day.
+	Weekdays []time.Weekday
+	// From and To bound the daily wall-clock window [From, To) as offsets
+	// from midnight. A zero To means the end of the day, so both zero allow
+	// the whole day.
+	From, To time.Duration
+}
+
+// TimeInterval returns a uniform time in [start, end) with nanosecond
+// granularity, in the location of start. It returns start if end is not after
+// start.
+func TimeInterval(start, end time.Time) time.Time {
+	return timeInterval(start, end, nil)
+}
+
+func timeInterval(start, end time.Time, r *Rand) time.Time {
+	if !end.After(start) {
+		return start
+	}
+	return timeSteps(start, end, 1, r.source())
+}
+
+// TimeIntervalWith returns a uniform time in [start, end) that satisfies opts.
+// Weekdays and the daily window are evaluated on the wall clock of
+// opts.Location, so on daylight saving transitions a window covers only the
+// wall-clock times that exist that day, and a repeated hour is covered twice.
+// The result is therefore always a valid wall-clock time in that zone.
+func TimeIntervalWith(start, end time.Time, opts *TimeOptions) (time.Time, error) {
+	return timeIntervalWith(start, end, opts, nil)
+}
+
+func timeIntervalWith(start, end time.Time, opts *TimeOptions, r *Rand) (time.Time, error) {
+	var o TimeOptions
+	if opts != nil {
+		o = *opts
+	}
+	if !end.After(start) {
+		return time.Time{}, ErrInvalidTimeRange
+	}
+	if o.Granularity < 0 || o.From < 0 || o.To < 0 || o.To > 24*time.Hour || (o.To != 0 && o.To <= o.From) || o.From >= 24*time.Hour {
+		return time.Time{}, ErrInvalidTimeOptions
+	}
+	if o.Granularity == 0 {
+		o.Granularity = 1
+	}
+	if o.Location == nil {
+		o.Location = start.Location()
+	}
+	if o.To == 0 {
+		o.To = 24 * time.Hour
+	}
+	var days [7]bool
+	for _, d := range o.Weekdays {
+		if d < time.Sunday || d > time.Saturday {
+			return time.Time{}, ErrInvalidTimeOptions
+		}
+		days[d] = true
+	}
+
+	rng := r.source()
+	if len(o.Weekdays) == 0 {
+		if o.From == 0 && o.To == 24*time.Hour {
+			return timeSteps(start.In(o.Location), end, uint64(o.Granularity), rng), nil
+		}
+		days = [7]bool{true, true, true, true, true, true, true}
+	}
+
+	// Count the candidate steps of every daily window overlapping the range,
+	// pick one uniformly, then walk the windows again to locate it.
+	var total uint128
+	eachTimeWindow(start, end, &o, days, func(_ time.Time, _, n uint64) bool {
+		total = total.add64(n)
+		return true
+	})
+	if total.isZero() {
+		return time.Time{}, ErrNoMatchingTime
+	}
+	idx := uniformUint128n(total, rng)
+	var t time.Time
+	eachTimeWindow(start, end, &o, days, func(anchor time.Time, first, n uint64) bool {
+		if idx.hi == 0 && idx.lo < n {
+			t = anchor.Add(time.Duration(first+idx.lo) * o.Granularity)
+			return false
+		}
+		idx = idx.sub64(n)
+		return true
+	})
+	return t, nil
+}
+
+// BusinessTime returns a uniform time in [start, end) that falls on a weekday
+// between 09:00 and 17:00 on the wall clock of loc.
+func BusinessTime(start, end time.Time, loc *time.Location) (time.Time, error) {
+	return timeIntervalWith(start, end, businessHours(loc), nil)
+}
+
+func businessHours(loc *time.Location) *TimeOptions {
+	return &TimeOptions{
+		Location: loc,
+		Weekdays: []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+		From:     9 * time.Hour,
+		To:       17 * time.Hour,
+	}
+}
+
+// timeSteps returns start plus a uniform multiple of step nanoseconds that is
+// before end. Spans wider than a time.Duration are handled in 128 bits.
+func timeSteps(start, end time.Time, step uint64, rng *wordRNG) time.Time {
+	span := uint128{lo: uint64(end.Unix() - start.Unix())}.mul64(1e9)
+	if ns := end.Nanosecond() - start.Nanosecond(); ns >= 0 {
+		span = span.add64(uint64(ns))
+	} else {
+		span = span.sub64(uint64(-ns))
+	}
+	n, rem := span.divmod64(step)
+	if rem != 0 {
+		n = n.add64(1)
+	}
+	sec, ns := uniformUint128n(n, rng).mul64(step).divmod64(1e9)
+	return time.Unix(start.Unix()+int64(sec.lo), int64(start.Nanosecond())+int64(ns)).In(start.Location())
+}
+
+// eachTimeWindow calls yield for every part of an allowed daily window that
+// overlaps [start, end), with the instant at which the wall clock reads From,
+// the index of the first step inside the range and the number of steps, until
+// yield returns false.
+//
+// A day is split into the periods during which the zone offset is constant.
+// Within a period the wall clock advances with real time, so the instants
+// whose wall-clock time falls in [From, To) form one interval. This leaves out
+// the wall-clock times skipped by a daylight saving gap and covers a repeated
+// hour twice.
+func eachTimeWindow(start, end time.Time, o *TimeOptions, days [7]bool, yield func(anchor time.Time, first, n uint64) bool) {
+	// Zone offsets lie within these bounds, so the instants showing a given
+	// wall-clock time are at most this far from the same reading in UTC.
+	const maxEast, maxWest = 15 * time.Hour, 13 * time.Hour
+	step := o.Granularity
+	y, m, d := start.In(o.Location).Date()
+	// Start a day early in case the clock is set back across midnight.
+	for i := -1; ; i++ {
+		day := time.Date(y, m, d+i, 0, 0, 0, 0, time.UTC)
+		from, to := day.Add(o.From), day.Add(o.To)
+		if !from.Add(-maxEast).Before(end) {
+			return
+		}
+		if !days[day.Weekday()] {
+			continue
+		}
+		for cursor := from.Add(-maxEast); cursor.Before(to.Add(maxWest)); {
+			local := cursor.In(o.Location)
+			_, offset := local.Zone()
+			periodStart, periodEnd := local.ZoneBounds()
+			shift := time.Duration(offset) * time.Second
+			anchor := from.Add(-shift)
+			lo, hi := anchor, to.Add(-shift)
+			if !periodStart.IsZero() && lo.Before(periodStart) {
+				lo = periodStart
+			}
+			if !periodEnd.IsZero() && hi.After(periodEnd) {
+				hi = periodEnd
+			}
+			if lo.Before(start) {
+				lo = start
+			}
+			if hi.After(end) {
+				hi = end
+			}
+			if lo.Before(hi) {
+				first := ceilSteps(lo.Sub(anchor), step)
+				last := ceilSteps(hi.Sub(anchor), step)
+				if last > first && !yield(anchor.In(o.Location), first, last-first) {
+					return
+				}
+			}
+			if periodEnd.IsZero() {
+				break
+			}
+			cursor = periodEnd
+		}
+	}
+}
+
+// ceilSteps returns the number of steps needed to cover the non-negative d.
+func ceilSteps(d, step time.Duration) uint64 {
+	n := uint64(d / step)
+	if d%step != 0 {
+		n++
+	}
+	return n
+}
+
+// DurationInterval returns a uniform duration in [min, max), like IntInterval.
+func DurationInterval(min, max time.Duration) time.Duration {
+	return IntInterval(min, max)
+}
+
+// ExpDuration returns an exponentially distributed duration with the given
+// mean, as for the time between independent events. It returns 0 if mean is
+// not positive and saturates at the largest time.Duration.
+func ExpDuration(mean time.Duration) time.Duration {
+	return expDuration(mean, nil)
+}
+
+func expDuration(mean time.Duration, r *Rand) time.Duration {
+	if mean <= 0 {
+		return 0
+	}
+	v := expRand(float64(mean), r.source())
+	if v >= math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return time.Duration(v)
+}
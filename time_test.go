@@ -0,0 +1,192 @@
+package randomizer_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/colduction/randomizer"
+)
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return loc
+}
+
+func TestTimeInterval(t *testing.T) {
+	loc := mustLoadLocation(t, "Asia/Tokyo")
+	start := time.Date(2024, 2, 29, 23, 59, 59, 999, loc)
+	end := start.Add(1500 * time.Millisecond)
+	for range 1000 {
+		got := randomizer.TimeInterval(start, end)
+		if got.Before(start) || !got.Before(end) {
+			t.Fatalf("TimeInterval = %v, want in [%v, %v)", got, start, end)
+		}
+		if got.Location() != loc {
+			t.Fatalf("location = %v, want %v", got.Location(), loc)
+		}
+	}
+	if got := randomizer.TimeInterval(end, start); !got.Equal(end) {
+		t.Fatalf("reversed range = %v, want start %v", got, end)
+	}
+
+	// Spans wider than a time.Duration still cover the whole range.
+	wideStart := time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC)
+	wideEnd := time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
+	var late bool
+	for range 100 {
+		got := randomizer.TimeInterval(wideStart, wideEnd)
+		if got.Before(wideStart) || !got.Before(wideEnd) {
+			t.Fatalf("wide TimeInterval = %v out of range", got)
+		}
+		late = late || got.Year() > 5000
+	}
+	if !late {
+		t.Fatal("wide range never produced a year after 5000")
+	}
+}
+
+func TestTimeIntervalWithGranularity(t *testing.T) {
+	start := time.Date(2025, 6, 1, 10, 7, 0, 0, time.UTC)
+	end := start.Add(2 * time.Hour)
+	seen := map[time.Time]bool{}
+	for range 2000 {
+		got, err := randomizer.TimeIntervalWith(start, end, &randomizer.TimeOptions{Granularity: 15 * time.Minute})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Sub(start)%(15*time.Minute) != 0 || got.Before(start) || !got.Before(end) {
+			t.Fatalf("got %v, want a 15 minute step from %v before %v", got, start, end)
+		}
+		seen[got] = true
+	}
+	if len(seen) != 8 {
+		t.Fatalf("saw %d distinct steps, want 8", len(seen))
+	}
+}
+
+func TestBusinessTime(t *testing.T) {
+	loc := mustLoadLocation(t, "Europe/Berlin")
+	start := time.Date(2026, 3, 20, 16, 30, 0, 0, loc) // Friday
+	end := time.Date(2026, 4, 3, 12, 0, 0, 0, loc)
+	for range 2000 {
+		got, err := randomizer.BusinessTime(start, end, loc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Location() != loc || got.Before(start) || !got.Before(end) {
+			t.Fatalf("BusinessTime = %v out of range", got)
+		}
+		if wd := got.Weekday(); wd == time.Saturday || wd == time.Sunday {
+			t.Fatalf("BusinessTime = %v falls on %v", got, wd)
+		}
+		if h := got.Hour(); h < 9 || h >= 17 {
+			t.Fatalf("BusinessTime = %v outside business hours", got)
+		}
+	}
+	weekend := time.Date(2026, 3, 21, 0, 0, 0, 0, loc)
+	if _, err := randomizer.BusinessTime(weekend, weekend.Add(48*time.Hour), loc); !errors.Is(err, randomizer.ErrNoMatchingTime) {
+		t.Fatalf("weekend err = %v, want ErrNoMatchingTime", err)
+	}
+}
+
+func TestTimeIntervalWithDST(t *testing.T) {
+	loc := mustLoadLocation(t, "America/New_York")
+
+	// 02:00-03:00 does not exist on 2026-03-08.
+	start := time.Date(2026, 3, 7, 0, 0, 0, 0, loc)
+	opts := &randomizer.TimeOptions{Location: loc, From: 2 * time.Hour, To: 3 * time.Hour}
+	for range 1000 {
+		got, err := randomizer.TimeIntervalWith(start, start.AddDate(0, 0, 3), opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Hour() != 2 || got.Day() == 8 {
+			t.Fatalf("got %v, want 02:xx on a day other than the transition", got)
+		}
+	}
+	gap := time.Date(2026, 3, 8, 0, 0, 0, 0, loc)
+	if _, err := randomizer.TimeIntervalWith(gap, gap.Add(12*time.Hour), opts); !errors.Is(err, randomizer.ErrNoMatchingTime) {
+		t.Fatalf("gap-only window err = %v, want ErrNoMatchingTime", err)
+	}
+
+	// 01:00-02:00 happens twice on 2026-11-01, once in each offset.
+	day := time.Date(2026, 11, 1, 0, 0, 0, 0, loc)
+	opts = &randomizer.TimeOptions{Location: loc, From: time.Hour, To: 2 * time.Hour, Granularity: time.Minute}
+	offsets := map[int]int{}
+	for range 1000 {
+		got, err := randomizer.TimeIntervalWith(day, day.Add(24*time.Hour), opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Hour() != 1 || got.Second() != 0 {
+			t.Fatalf("got %v, want a whole minute in 01:xx", got)
+		}
+		_, off := got.Zone()
+		offsets[off]++
+	}
+	if len(offsets) != 2 {
+		t.Fatalf("repeated hour drawn from offsets %v, want both", offsets)
+	}
+
+	// Windows straddling the gap keep only existing wall-clock times.
+	opts = &randomizer.TimeOptions{Location: loc, From: 90 * time.Minute, To: 210 * time.Minute}
+	for range 1000 {
+		got, err := randomizer.TimeIntervalWith(gap, gap.Add(24*time.Hour), opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		wall := time.Duration(got.Hour())*time.Hour + time.Duration(got.Minute())*time.Minute
+		if wall < opts.From || wall >= opts.To || got.Hour() == 2 {
+			t.Fatalf("got %v outside the window", got)
+		}
+	}
+}
+
+func TestTimeIntervalWithErrors(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	if _, err := randomizer.TimeIntervalWith(end, start, nil); !errors.Is(err, randomizer.ErrInvalidTimeRange) {
+		t.Fatalf("err = %v, want ErrInvalidTimeRange", err)
+	}
+	for _, opts := range []randomizer.TimeOptions{
+		{Granularity: -1},
+		{From: 5 * time.Hour, To: 4 * time.Hour},
+		{To: 25 * time.Hour},
+		{From: 24 * time.Hour},
+		{Weekdays: []time.Weekday{7}},
+	} {
+		if _, err := randomizer.TimeIntervalWith(start, end, &opts); !errors.Is(err, randomizer.ErrInvalidTimeOptions) {
+			t.Errorf("%+v: err = %v, want ErrInvalidTimeOptions", opts, err)
+		}
+	}
+}
+
+func TestDurations(t *testing.T) {
+	for range 1000 {
+		if d := randomizer.DurationInterval(time.Second, 2*time.Second); d < time.Second || d >= 2*time.Second {
+			t.Fatalf("DurationInterval = %v", d)
+		}
+	}
+	const n = 20000
+	var sum float64
+	for range n {
+		d := randomizer.ExpDuration(time.Second)
+		if d < 0 {
+			t.Fatalf("ExpDuration = %v", d)
+		}
+		sum += d.Seconds()
+	}
+	if mean := sum / n; math.Abs(mean-1) > 0.05 {
+		t.Fatalf("ExpDuration mean = %.3fs, want about 1s", mean)
+	}
+	if d := randomizer.ExpDuration(0); d != 0 {
+		t.Fatalf("ExpDuration(0) = %v", d)
+	}
+}
@@ -0,0 +1,150 @@
+package randomizer
+
+import (
+	"encoding/binary"
+	"math/bits"
+	"net/netip"
+)
+
+// uint128 is an unsigned 128-bit integer used for IPv6 address arithmetic
+// and for nanosecond spans too wide for a time.Duration.
+type uint128 struct {
+	hi, lo uint64
+}
+
+func u128FromAddr(a netip.Addr) uint128 {
+	if a.Is4() {
+		b := a.As4()
+		return uint128{lo: uint64(b[0])<<24 | uint64(b[1])<<16 | uint64(b[2])<<8 | uint64(b[3])}
+	}
+	b := a.As16()
+	return uint128{hi: binary.BigEndian.Uint64(b[:8]), lo: binary.BigEndian.Uint64(b[8:])}
+}
+
+// addr converts u to an IPv4 address if is4 is set, otherwise to an IPv6 address.
+func (u uint128) addr(is4 bool) netip.Addr {
+	if is4 {
+		return netip.AddrFrom4([4]byte{byte(u.lo >> 24), byte(u.lo >> 16), byte(u.lo >> 8), byte(u.lo)})
+	}
+	var b [16]byte
+	binary.BigEndian.PutUint64(b[:8], u.hi)
+	binary.BigEndian.PutUint64(b[8:], u.lo)
+	return netip.AddrFrom16(b)
+}
+
+func (u uint128) isZero() bool {
+	return u.hi == 0 && u.lo == 0
+}
+
+func (u uint128) cmp(v uint128) int {
+	switch {
+	case u.hi < v.hi:
+		return -1
+	case u.hi > v.hi:
+		return 1
+	case u.lo < v.lo:
+		return -1
+	case u.lo > v.lo:
+		return 1
+	}
+	return 0
+}
+
+func (u uint128) add(v uint128) uint128 {
+	lo, carry := bits.Add64(u.lo, v.lo, 0)
+	hi, _ := bits.Add64(u.hi, v.hi, carry)
+	return uint128{hi, lo}
+}
+
+func (u uint128) sub(v uint128) uint128 {
+	lo, borrow := bits.Sub64(u.lo, v.lo, 0)
+	hi, _ := bits.Sub64(u.hi, v.hi, borrow)
+	return uint128{hi, lo}
+}
+
+// mul64 returns u*v, discarding any overflow beyond 128 bits.
+func (u uint128) mul64(v uint64) uint128 {
+	hi, lo := bits.Mul64(u.lo, v)
+	return uint128{hi + u.hi*v, lo}
+}
+
+// divmod64 returns u/v and u%v.
+func (u uint128) divmod64(v uint64) (uint128, uint64) {
+	qhi, r := u.hi/v, u.hi%v
+	qlo, r := bits.Div64(r, u.lo, v)
+	return uint128{qhi, qlo}, r
+}
+
+func (u uint128) add64(v uint64) uint128 {
+	return u.add(uint128{lo: v})
+}
+
+func (u uint128) sub64(v uint64) uint128 {
+	return u.sub(uint128{lo: v})
+}
+
+func (u uint128) and(v uint128) uint128 {
+	return uint128{u.hi & v.hi, u.lo & v.lo}
+}
+
+func (u uint128) or(v uint128) uint128 {
+	return uint128{u.hi | v.hi, u.lo | v.lo}
+}
+
+func (u uint128) xor(v uint128) uint128 {
+	return uint128{u.hi ^ v.hi, u.lo ^ v.lo}
+}
+
+// shl returns u shifted left by n bits.
+func (u uint128) shl(n int) uint128 {
+	switch {
+	case n <= 0:
+		return u
+	case n >= 128:
+		return uint128{}
+	case n >= 64:
+		return uint128{hi: u.lo << (n - 64)}
+	default:
+		return uint128{u.hi<<n | u.lo>>(64-n), u.lo << n}
+	}
+}
+
+// lowMask128 returns a value with the n least significant bits set.
+func lowMask128(n int) uint128 {
+	switch {
+	case n <= 0:
+		return uint128{}
+	case n < 64:
+		return uint128{lo: 1<<n - 1}
+	case n < 128:
+		return uint128{hi: 1<<(n-64) - 1, lo: ^uint64(0)}
+	default:
+		return uint128{^uint64(0), ^uint64(0)}
+	}
+}
+
+// bitLen returns the minimum number of bits needed to represent u.
+func (u uint128) bitLen() int {
+	if u.hi != 0 {
+		return 64 + bits.Len64(u.hi)
+	}
+	return bits.Len64(u.lo)
+}
+
+// randomBits128 returns a uniform value with only the n least significant bits set at random.
+func randomBits128(n int, rng *wordRNG) uint128 {
+	return uint128{rng.next64(), rng.next64()}.and(lowMask128(n))
+}
+
+// uniformUint128n returns a uniform value in [0, n).
+func uniformUint128n(n uint128, rng *wordRNG) uint128 {
+	if n.hi == 0 {
+		return uint128{lo: uniformUint64n(n.lo, rng)}
+	}
+	width := n.sub64(1).bitLen()
+	for {
+		if v := randomBits128(width, rng); v.cmp(n) < 0 {
+			return v
+		}
+	}
+}
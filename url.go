@@ -0,0 +1,241 @@
+package randomizer
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// HostKind selects the kind of host used by Network.URL.
+type HostKind uint8
+
+const (
+	// DomainHost uses a name from Network.Domain.
+	DomainHost HostKind = iota
+	// IPv4Host uses an address from Network.Addr4.
+	IPv4Host
+	// IPv6Host uses a bracketed address from Network.Addr6.
+	IPv6Host
+	// AnyHost picks one of the above at random.
+	AnyHost
+)
+
+// URLOptions configures Network.URL. The zero value produces an http or
+// https URL on a random domain with up to three path segments.
+type URLOptions struct {
+	// Scheme fixes the scheme; empty picks http or https.
+	Scheme string
+	// Host selects the kind of host.
+	Host HostKind
+	// Domain configures domain hosts; nil uses Network.Domain defaults.
+	Domain *DomainOptions
+	// Port adds an explicit port from Network.Port.
+	Port bool
+	// MaxPathSegments bounds the number of path segments; zero means 3.
+	MaxPathSegments int
+	// MaxQueryParams bounds the number of query parameters.
+	MaxQueryParams int
+	// Fragment adds a fragment.
+	Fragment bool
+}
+
+// urlTextRunes mixes unreserved characters with characters that must be
+// percent-encoded in URL components.
+var urlTextRunes = []rune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-._~ !$&'()*+,;=:@/?#%[]\"<>^`{|}éüñø中文")
+
+// urlText returns random URL component text of 1 to 12 runes, weighted toward
+// unreserved characters so that most output stays readable.
+func urlText(rng *wordRNG) string {
+	const unreserved = 66 // length of the leading unreserved run of urlTextRunes
+	n := 1 + uniformUint64n(12, rng)
+	out := make([]byte, 0, n)
+	for range n {
+		set := urlTextRunes[:unreserved]
+		if uniformUint64n(4, rng) == 0 {
+			set = urlTextRunes
+		}
+		out = utf8.AppendRune(out, set[uniformUint64n(uint64(len(set)), rng)])
+	}
+	return string(out)
+}
+
+// URL generates a random absolute URL whose path segments, query parameters
+// and fragment contain characters that are percent-encoded as RFC 3986
+// requires, so that url.Parse recovers the original component values.
+// A nil opts behaves like the zero URLOptions. It returns an empty string if
+// Network.Domain rejects opts.Domain.
+func (n network) URL(opts *URLOptions) string {
+	if opts == nil {
+		opts = new(URLOptions)
+	}
+	if _, ok := opts.Domain.tld(); !ok {
+		return ""
+	}
+	rng := n.r.source()
+
+	scheme := opts.Scheme
+	if scheme == "" {
+		scheme = "http"
+		if uniformUint64n(2, rng) == 0 {
+			scheme = "https"
+		}
+	}
+	out := append([]byte(scheme), "://"...)
+
+	kind := opts.Host
+	if kind == AnyHost {
+		kind = HostKind(uniformUint64n(uint64(AnyHost), rng))
+	}
+	switch kind {
+	case IPv4Host:
+		out = n.Addr4().AppendTo(out)
+	case IPv6Host:
+		out = append(n.Addr6().AppendTo(append(out, '[')), ']')
+	default:
+		out = append(out, n.Domain(opts.Domain)...)
+	}
+	if opts.Port {
+		port, _ := pickPort(nil, rng)
+		out = strconv.AppendUint(append(out, ':'), uint64(port), 10)
+	}
+
+	maxSegments := opts.MaxPathSegments
+	if maxSegments <= 0 {
+		maxSegments = 3
+	}
+	segments := uniformUint64n(uint64(maxSegments)+1, rng)
+	if segments == 0 {
+		out = append(out, '/')
+	}
+	for range segments {
+		out = append(append(out, '/'), url.PathEscape(urlText(rng))...)
+	}
+
+	if opts.MaxQueryParams > 0 {
+		params := uniformUint64n(uint64(opts.MaxQueryParams)+1, rng)
+		for i := range params {
+			sep := byte('&')
+			if i == 0 {
+				sep = '?'
+			}
+			out = append(out, sep)
+			out = append(out, url.QueryEscape(urlText(rng))...)
+			out = append(out, '=')
+			out = append(out, url.QueryEscape(urlText(rng))...)
+		}
+	}
+	if opts.Fragment {
+		out = append(append(out, '#'), url.PathEscape(urlText(rng))...)
+	}
+	return string(out)
+}
+
+// EmailOptions configures Network.Email. The zero value produces common
+// dot-atom addresses on a random domain.
+type EmailOptions struct {
+	// Domain configures the domain part; nil uses Network.Domain defaults.
+	Domain *DomainOptions
+	// EdgeCases produces unusual but valid RFC 5322 forms: the full atext
+	// character set, quoted local parts with spaces and escapes, and
+	// IPv4 or IPv6 address literals as the domain.
+	EdgeCases bool
+	// Unicode allows RFC 6531 internationalized local parts in UTF-8.
+	Unicode bool
+}
+
+// Email address limits.
+// ref: https://datatracker.ietf.org/doc/html/rfc5321#section-4.5.3.1
+const (
+	maxEmailLen = 254
+	// emailCommon is the conservative subset of atext used by default.
+	emailCommon string = "abcdefghijklmnopqrstuvwxyz0123456789"
+	emailInner  string = "abcdefghijklmnopqrstuvwxyz0123456789_-+"
+	// emailAtext is every RFC 5322 atext character.
+	emailAtext string = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789!#$%&'*+-/=?^_`{|}~"
+	// emailQtext is a selection of characters valid inside a quoted string,
+	// with \" and \\ appended as quoted pairs.
+	emailQtext string = "abcdefghijklmnopqrstuvwxyz0123456789 .,:;@()<>[]!#$%&'*+-/=?^_`{|}~"
+)
+
+// emailUnicodeRunes are lowercase letters used for RFC 6531 local parts.
+var emailUnicodeRunes = []rune("αβγδεζηθλμπστφωабвгдежзиклмнопрстуфяàáâäçèéêëñöüß中文日本語")
+
+// appendDotAtom appends n characters drawn from dict, which must not contain
+// a dot, replacing some interior characters with single dots.
+func appendDotAtom(out []byte, n int, dict string, rng *wordRNG) []byte {
+	for i := range n {
+		if i > 0 && i < n-1 && out[len(out)-1] != '.' && uniformUint64n(8, rng) == 0 {
+			out = append(out, '.')
+			continue
+		}
+		out = append(out, dict[uniformUint64n(uint64(len(dict)), rng)])
+	}
+	return out
+}
+
+func appendEmailLocal(out []byte, opts *EmailOptions, rng *wordRNG) []byte {
+	n := 3 + int(uniformUint64n(14, rng))
+	switch {
+	case opts.Unicode && uniformUint64n(2, rng) == 0:
+		for range n {
+			out = utf8.AppendRune(out, emailUnicodeRunes[uniformUint64n(uint64(len(emailUnicodeRunes)), rng)])
+		}
+		return out
+	case opts.EdgeCases && uniformUint64n(2, rng) == 0:
+		out = append(out, '"')
+		for range n {
+			switch uniformUint64n(10, rng) {
+			case 0:
+				out = append(out, '\\', '"')
+			case 1:
+				out = append(out, '\\', '\\')
+			default:
+				out = append(out, emailQtext[uniformUint64n(uint64(len(emailQtext)), rng)])
+			}
+		}
+		return append(out, '"')
+	case opts.EdgeCases:
+		return appendDotAtom(out, n, emailAtext, rng)
+	}
+	out = append(out, emailCommon[uniformUint64n(uint64(len(emailCommon)), rng)])
+	out = appendDotAtom(out, n-2, emailInner, rng)
+	return append(out, emailCommon[uniformUint64n(uint64(len(emailCommon)), rng)])
+}
+
+// Email generates a random syntactically valid email address. The local part
+// is at most 64 bytes and the whole address at most 254 bytes. A nil opts
+// behaves like the zero EmailOptions. It returns an empty string if
+// Network.Domain rejects opts.Domain.
+func (n network) Email(opts *EmailOptions) string {
+	if opts == nil {
+		opts = new(EmailOptions)
+	}
+	if _, ok := opts.Domain.tld(); !ok {
+		return ""
+	}
+	rng := n.r.source()
+	// Local parts are at most 16 units of up to 3 bytes each, plus quotes,
+	// so they always fit in the 64-byte limit.
+	out := appendEmailLocal(make([]byte, 0, 48), opts, rng)
+	out = append(out, '@')
+	if opts.EdgeCases && uniformUint64n(4, rng) == 0 {
+		out = append(out, '[')
+		if uniformUint64n(2, rng) == 0 {
+			out = n.Addr4().AppendTo(out)
+		} else {
+			out = n.Addr6().AppendTo(append(out, "IPv6:"...))
+		}
+		return string(append(out, ']'))
+	}
+	domain := n.Domain(opts.Domain)
+	// Drop leading labels until the address fits.
+	for len(out)+len(domain) > maxEmailLen {
+		i := strings.IndexByte(domain, '.')
+		if i < 0 {
+			break
+		}
+		domain = domain[i+1:]
+	}
+	return string(append(out, domain...))
+}
@@ -0,0 +1,139 @@
+package randomizer_test
+
+import (
+	"net/mail"
+	"net/netip"
+	"net/url"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/colduction/randomizer"
+)
+
+func TestNetworkURL(t *testing.T) {
+	opts := &randomizer.URLOptions{Host: randomizer.AnyHost, Port: true, MaxQueryParams: 3, Fragment: true}
+	for range 2000 {
+		s := randomizer.Network.URL(opts)
+		u, err := url.Parse(s)
+		if err != nil {
+			t.Fatalf("url.Parse(%q): %v", s, err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			t.Fatalf("%q has scheme %q", s, u.Scheme)
+		}
+		if u.Port() == "" {
+			t.Fatalf("%q has no port", s)
+		}
+		if strings.HasPrefix(u.Host, "[") {
+			if a, err := netip.ParseAddr(u.Hostname()); err != nil || !a.Is6() {
+				t.Fatalf("%q has invalid IPv6 host", s)
+			}
+		}
+		if _, err := url.ParseQuery(u.RawQuery); err != nil {
+			t.Fatalf("%q has invalid query: %v", s, err)
+		}
+		if !utf8.ValidString(u.Path) || !utf8.ValidString(u.Fragment) {
+			t.Fatalf("%q decodes to invalid UTF-8", s)
+		}
+		// Percent-encoding round-trips: re-escaping yields the same URL.
+		if got := u.String(); got != s {
+			t.Fatalf("url.Parse(%q).String() = %q", s, got)
+		}
+	}
+}
+
+func TestNetworkURLOptions(t *testing.T) {
+	opts := &randomizer.URLOptions{
+		Scheme:          "https",
+		Host:            randomizer.DomainHost,
+		Domain:          &randomizer.DomainOptions{TLD: "test"},
+		MaxPathSegments: 1,
+	}
+	for range 200 {
+		u, err := url.Parse(randomizer.Network.URL(opts))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if u.Scheme != "https" || !strings.HasSuffix(u.Host, ".test") || u.RawQuery != "" || u.Fragment != "" {
+			t.Fatalf("unexpected URL %q", u)
+		}
+		if strings.Count(u.EscapedPath(), "/") != 1 {
+			t.Fatalf("%q has more than one path segment", u)
+		}
+	}
+	u, _ := url.Parse(randomizer.Network.URL(&randomizer.URLOptions{Host: randomizer.IPv4Host}))
+	if a, err := netip.ParseAddr(u.Host); err != nil || !a.Is4() {
+		t.Fatalf("IPv4Host produced host %q", u.Host)
+	}
+}
+
+func checkEmail(t *testing.T, s string) {
+	t.Helper()
+	if len(s) > 254 {
+		t.Fatalf("%q is longer than 254 bytes", s)
+	}
+	if _, err := mail.ParseAddress(s); err != nil {
+		t.Fatalf("mail.ParseAddress(%q): %v", s, err)
+	}
+	at := strings.LastIndexByte(s, '@')
+	if at < 1 || at > 64 {
+		t.Fatalf("%q has local part of %d bytes", s, at)
+	}
+}
+
+func TestNetworkEmail(t *testing.T) {
+	for range 2000 {
+		s := randomizer.Network.Email(nil)
+		checkEmail(t, s)
+		if strings.ContainsAny(s, "\"[") {
+			t.Fatalf("Email(nil) = %q, want dot-atom form", s)
+		}
+	}
+}
+
+func TestNetworkEmailEdgeCases(t *testing.T) {
+	var quoted, literal, unicode bool
+	opts := &randomizer.EmailOptions{EdgeCases: true, Unicode: true}
+	for range 2000 {
+		s := randomizer.Network.Email(opts)
+		checkEmail(t, s)
+		quoted = quoted || s[0] == '"'
+		literal = literal || strings.HasSuffix(s, "]")
+		unicode = unicode || !isASCII(s)
+	}
+	if !quoted || !literal || !unicode {
+		t.Fatalf("edge cases: quoted=%v literal=%v unicode=%v", quoted, literal, unicode)
+	}
+}
+
+func TestNetworkEmailLongDomain(t *testing.T) {
+	opts := &randomizer.EmailOptions{Domain: &randomizer.DomainOptions{Labels: 10, MinLabelLen: 63}}
+	for range 100 {
+		checkEmail(t, randomizer.Network.Email(opts))
+	}
+}
+
+func isASCII(s string) bool {
+	for i := range len(s) {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+func BenchmarkNetworkURL(b *testing.B) {
+	opts := &randomizer.URLOptions{MaxQueryParams: 2, Fragment: true}
+	b.ReportAllocs()
+	for b.Loop() {
+		benchWordString = randomizer.Network.URL(opts)
+	}
+}
+
+func BenchmarkNetworkEmail(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		benchWordString = randomizer.Network.Email(nil)
+	}
+}
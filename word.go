@@ -1,13 +1,19 @@
 package randomizer
 
 const (
-	deci     string = "0123456789"
-	octi     string = "01234567"
-	lhexdict string = "0123456789abcdef"
-	uhexdict string = "0123456789ABCDEF"
+	deci         string = "0123456789"
+	octi         string = "01234567"
+	lhexdict     string = "0123456789abcdef"
+	uhexdict     string = "0123456789ABCDEF"
+	alphadict    string = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	alphanumdict string = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	ualphadict   string = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	ualnumdict   string = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ"
 )
 
-type word struct{}
+type word struct {
+	r *Rand // nil draws from DefaultHashPool
+}
 
 var Word word
 
@@ -29,6 +35,12 @@ func (r *wordRNG) next64() uint64 {
 	return z ^ (z >> 31)
 }
 
+// float64 returns a uniform value in [0, 1) built from the upper 53 bits of next64.
+func (r *wordRNG) float64() float64 {
+	const inv53 = float64(1.0 / (1 << 53))
+	return float64(r.next64()>>11) * inv53
+}
+
 func fillDecimalNoRepeat(out []byte, rng *wordRNG) {
 	// Largest multiple of 10 below 256 to remove modulo bias.
 	const cutoff = 250
@@ -64,6 +76,39 @@ func fillDecimalNoRepeat(out []byte, rng *wordRNG) {
 	}
 }
 
+// fillDecimal fills out with uniformly distributed decimal digits, allowing repeats.
+func fillDecimal(out []byte, rng *wordRNG) {
+	// Largest multiple of 10 below 256 to remove modulo bias.
+	const cutoff = 250
+
+	var (
+		raw      uint64
+		rawBytes uint8
+	)
+	for i := 0; i < len(out); {
+		if rawBytes == 0 {
+			raw = rng.next64()
+			rawBytes = 8
+		}
+		v := byte(raw)
+		raw >>= 8
+		rawBytes--
+		if v >= cutoff {
+			continue
+		}
+		out[i] = deci[v%10]
+		i++
+	}
+}
+
+// fillAlphabet fills out with bytes drawn uniformly from dict, allowing repeats.
+func fillAlphabet(out []byte, dict string, rng *wordRNG) {
+	n := uint64(len(dict))
+	for i := range out {
+		out[i] = dict[uniformUint64n(n, rng)]
+	}
+}
+
 func fillPow2AlphabetNoRepeat(out []byte, dict string, bits uint8, rng *wordRNG) {
 	mask := uint64((1 << bits) - 1)
 
@@ -95,32 +140,32 @@ func fillPow2AlphabetNoRepeat(out []byte, dict string, bits uint8, rng *wordRNG)
 
 // Decimal generates a random numeric string of the specified length,
 // consisting of characters from "0123456789".
-func (word) Decimal(length int) string {
+func (w word) Decimal(length int) string {
 	if length <= 0 {
 		return ""
 	}
 	out := make([]byte, length)
-	rng := newWordRNG()
-	fillDecimalNoRepeat(out, &rng)
+	rng := w.r.source()
+	fillDecimalNoRepeat(out, rng)
 	return string(out)
 }
 
 // DecimalBytes generates a random numeric byte slice of the specified length,
 // consisting of digits from "0123456789".
-func (word) DecimalBytes(length int) []byte {
+func (w word) DecimalBytes(length int) []byte {
 	if length <= 0 {
 		return nil
 	}
 	out := make([]byte, length)
-	rng := newWordRNG()
-	fillDecimalNoRepeat(out, &rng)
+	rng := w.r.source()
+	fillDecimalNoRepeat(out, rng)
 	return out
 }
 
 // Hex generates a random hexadecimal string of the specified length.
 // If the uppercase parameter is true, the generated string will use uppercase letters (A-F),
 // otherwise, it will use lowercase letters (a-f).
-func (word) Hex(length int, uppercase bool) string {
+func (w word) Hex(length int, uppercase bool) string {
 	if length <= 0 {
 		return ""
 	}
@@ -129,15 +174,15 @@ func (word) Hex(length int, uppercase bool) string {
 		dict = uhexdict
 	}
 	out := make([]byte, length)
-	rng := newWordRNG()
-	fillPow2AlphabetNoRepeat(out, dict, 4, &rng)
+	rng := w.r.source()
+	fillPow2AlphabetNoRepeat(out, dict, 4, rng)
 	return string(out)
 }
 
 // HexBytes generates a random hexadecimal byte slice of the specified length.
 // If the uppercase parameter is true, the generated bytes will use uppercase letters (A-F),
 // otherwise, it will use lowercase letters (a-f).
-func (word) HexBytes(length int, uppercase bool) []byte {
+func (w word) HexBytes(length int, uppercase bool) []byte {
 	if length <= 0 {
 		return nil
 	}
@@ -146,31 +191,31 @@ func (word) HexBytes(length int, uppercase bool) []byte {
 		dict = uhexdict
 	}
 	out := make([]byte, length)
-	rng := newWordRNG()
-	fillPow2AlphabetNoRepeat(out, dict, 4, &rng)
+	rng := w.r.source()
+	fillPow2AlphabetNoRepeat(out, dict, 4, rng)
 	return out
 }
 
 // Octal generates a random octal string of the specified length,
 // consisting of characters from "01234567".
-func (word) Octal(length int) string {
+func (w word) Octal(length int) string {
 	if length <= 0 {
 		return ""
 	}
 	out := make([]byte, length)
-	rng := newWordRNG()
-	fillPow2AlphabetNoRepeat(out, octi, 3, &rng)
+	rng := w.r.source()
+	fillPow2AlphabetNoRepeat(out, octi, 3, rng)
 	return string(out)
 }
 
 // OctalBytes generates a random octal byte slice of the specified length,
 // consisting of digits from "01234567".
-func (word) OctalBytes(length int) []byte {
+func (w word) OctalBytes(length int) []byte {
 	if length <= 0 {
 		return nil
 	}
 	out := make([]byte, length)
-	rng := newWordRNG()
-	fillPow2AlphabetNoRepeat(out, octi, 3, &rng)
+	rng := w.r.source()
+	fillPow2AlphabetNoRepeat(out, octi, 3, rng)
 	return out
 }